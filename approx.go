@@ -0,0 +1,197 @@
+package wish
+
+import (
+	"fmt"
+	"math"
+	"math/cmplx"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/warpfork/go-wish/cmp"
+)
+
+// ShouldBeWithin returns a Checker which asserts that two values are equal
+// in the same way as ShouldEqual, except that floating point and complex
+// numbers are considered equal if they differ by no more than the tolerance.
+// (For complex numbers, the difference is the absolute value of x-y.)
+// Values of named types, like `type Celsius float64`, are numbers too.
+//
+// The tolerance applies recursively: floats anywhere inside structs, slices,
+// maps, and so on are all compared approximately.  All other values,
+// including integers, must still be exactly equal.
+// NaN is never within any tolerance of anything, including another NaN.
+//
+// For example:
+//
+//	Wish(t, point, ShouldBeWithin(0.001), Point{X: 0.5, Y: 1.5})
+//
+// If the check is rejected, the rejection message lists each value that
+// differs, by its path within the value, and for each approximately compared
+// value, how far apart the values were and what tolerance was allowed.
+//
+// The CmpOptions option may be used with this Checker, as with ShouldEqual.
+func ShouldBeWithin(tolerance float64) Checker {
+	if tolerance < 0 || math.IsNaN(tolerance) {
+		panic("ShouldBeWithin tolerance must be a non-negative number")
+	}
+	return wrapChecker("ShouldBeWithin", func(cfg *config) (Checker, bool) {
+		return func(actual interface{}, desire interface{}) (problem string, passed bool) {
+			return checkWithin(cfg, actual, desire, approxNumbers(tolerance), func(vx, vy reflect.Value) string {
+				return fmt.Sprintf("differs by %g; tolerance is %g", numericDistance(vx, vy), tolerance)
+			})
+		}, cfg.unaccepted("CmpOptions") == ""
+	})
+}
+
+// ShouldBeWithinDuration returns a Checker which asserts that two values are
+// equal in the same way as ShouldEqual, except that time.Time values (and
+// time.Duration values) are considered equal if they differ by no more than
+// the tolerance.  Values of types defined as time.Time are compared the same
+// way.  Other types, even those defined as time.Duration, are compared
+// exactly: reflection can't tell them apart from any other int64 type,
+// such as an ID or a counter.
+//
+// Like ShouldBeWithin, the tolerance applies recursively, and the rejection
+// message reports how far apart each mismatched time was.
+func ShouldBeWithinDuration(tolerance time.Duration) Checker {
+	if tolerance < 0 {
+		panic("ShouldBeWithinDuration tolerance must not be negative")
+	}
+	return wrapChecker("ShouldBeWithinDuration", func(cfg *config) (Checker, bool) {
+		return func(actual interface{}, desire interface{}) (problem string, passed bool) {
+			return checkWithin(cfg, actual, desire, approxTimes(tolerance), func(vx, vy reflect.Value) string {
+				return fmt.Sprintf("differs by %s; tolerance is %s", timeDistance(vx, vy), tolerance)
+			})
+		}, cfg.unaccepted("CmpOptions") == ""
+	})
+}
+
+// approximation says which types of values are compared approximately,
+// and how close two such values must be.
+type approximation struct {
+	applies func(reflect.Type) bool
+	within  func(vx, vy reflect.Value) bool
+}
+
+// option is the cmp.Option which compares values of the approximated types.
+// It's applied by kind (or by convertibility), not by exact type,
+// so named types like `type Celsius float64` are approximated too.
+func (a approximation) option() cmp.Option {
+	return cmp.FilterValues(func(x, y interface{}) bool {
+		tx := reflect.TypeOf(x)
+		return tx != nil && tx == reflect.TypeOf(y) && a.applies(tx)
+	}, cmp.Comparer(func(x, y interface{}) bool {
+		return a.within(reflect.ValueOf(x), reflect.ValueOf(y))
+	}))
+}
+
+// checkWithin does the comparison for ShouldBeWithin and its relatives.
+// The explain func is called to describe the distance between two unequal
+// values of any of the approximated types.  Any CmpOptions in the config
+// are used too.
+func checkWithin(cfg *config, actual, desire interface{}, approx approximation, explain func(vx, vy reflect.Value) string) (problem string, passed bool) {
+	r := toleranceReporter{explain: explain, approx: approx}
+	opts := append([]cmp.Option{cmp.Reporter(&r), approx.option()}, cfg.cmpOpts...)
+	if cmp.Equal(actual, desire, opts...) {
+		return "", true
+	}
+	return r.sb.String(), false
+}
+
+func approxNumbers(tolerance float64) approximation {
+	return approximation{
+		applies: func(t reflect.Type) bool {
+			switch t.Kind() {
+			case reflect.Float32, reflect.Float64, reflect.Complex64, reflect.Complex128:
+				return true
+			}
+			return false
+		},
+		within: func(vx, vy reflect.Value) bool {
+			return numericDistance(vx, vy) <= tolerance
+		},
+	}
+}
+
+var (
+	timeType     = reflect.TypeOf(time.Time{})
+	durationType = reflect.TypeOf(time.Duration(0))
+)
+
+func approxTimes(tolerance time.Duration) approximation {
+	return approximation{
+		applies: isTimeOrDuration,
+		within: func(vx, vy reflect.Value) bool {
+			return timeDistance(vx, vy) <= tolerance
+		},
+	}
+}
+
+// isTimeOrDuration reports whether values of the type are compared by
+// ShouldBeWithinDuration: time.Time and types defined as it, and
+// time.Duration.
+func isTimeOrDuration(t reflect.Type) bool {
+	switch {
+	case t == timeType || t == durationType:
+		return true
+	case t.Kind() == reflect.Struct:
+		return t.ConvertibleTo(timeType)
+	}
+	return false
+}
+
+func absDuration(d time.Duration) time.Duration {
+	if d < 0 {
+		return -d
+	}
+	return d
+}
+
+func numericDistance(vx, vy reflect.Value) float64 {
+	switch vx.Kind() {
+	case reflect.Complex64, reflect.Complex128:
+		return cmplx.Abs(vx.Complex() - vy.Complex())
+	default:
+		return math.Abs(vx.Float() - vy.Float())
+	}
+}
+
+func timeDistance(vx, vy reflect.Value) time.Duration {
+	if vx.Type() == durationType {
+		return absDuration(time.Duration(vx.Int() - vy.Int()))
+	}
+	return absDuration(vx.Convert(timeType).Interface().(time.Time).Sub(vy.Convert(timeType).Interface().(time.Time)))
+}
+
+// toleranceReporter is a cmp.Reporter which describes each unequal leaf,
+// with an explanation of the distance for those compared approximately.
+type toleranceReporter struct {
+	explain func(vx, vy reflect.Value) string
+	approx  approximation
+	path    cmp.Path
+	sb      strings.Builder
+}
+
+func (r *toleranceReporter) PushStep(ps cmp.PathStep) { r.path = append(r.path, ps) }
+func (r *toleranceReporter) PopStep()                 { r.path = r.path[:len(r.path)-1] }
+func (r *toleranceReporter) Report(rs cmp.Result) {
+	if rs.Equal() {
+		return
+	}
+	vx, vy := r.path.Last().Values()
+	r.sb.WriteString("root" + r.path[1:].GoString() + ":")
+	if vx.IsValid() && vy.IsValid() && vx.Type() == vy.Type() && r.approx.applies(vx.Type()) && vx.CanInterface() {
+		r.sb.WriteString(" " + r.explain(vx, vy))
+	}
+	fmt.Fprintf(&r.sb, "\n\t- %s\n\t+ %s\n", renderLeaf(vx), renderLeaf(vy))
+}
+
+// renderLeaf renders a single value for a report, or notes its absence
+// (when the other side has a value there).
+func renderLeaf(rv reflect.Value) string {
+	if !rv.IsValid() {
+		return "(absent)"
+	}
+	return fmt.Sprintf("%#v", rv)
+}
@@ -0,0 +1,89 @@
+package wish
+
+import (
+	"testing"
+	"time"
+)
+
+type measurement struct {
+	Label  string
+	Values []float64
+	Phase  complex128
+	Count  int
+}
+
+func TestShouldBeWithin(t *testing.T) {
+	t.Run("plain floats", func(t *testing.T) {
+		_, passed := ShouldBeWithin(0.01)(1.0, 1.005)
+		Wish(t, passed, ShouldEqual, true)
+		msg, _ := ShouldBeWithin(0.01)(1.0, 1.5)
+		shouldStringMatch(t, msg, Dedent(`
+			root: differs by 0.5; tolerance is 0.01
+				- 1
+				+ 1.5
+		`))
+	})
+	t.Run("recursively", func(t *testing.T) {
+		actual := measurement{"m", []float64{1, 2, 3}, complex(0, 1), 4}
+		_, passed := ShouldBeWithin(0.01)(actual, measurement{"m", []float64{1, 2.001, 3}, complex(0.005, 1), 4})
+		Wish(t, passed, ShouldEqual, true)
+		msg, _ := ShouldBeWithin(0.01)(actual, measurement{"m", []float64{1, 2.25, 3}, complex(0, 1), 5})
+		shouldStringMatch(t, msg, Dedent(`
+			root.Values[1]: differs by 0.25; tolerance is 0.01
+				- 2
+				+ 2.25
+			root.Count:
+				- 4
+				+ 5
+		`))
+	})
+	t.Run("named types", func(t *testing.T) {
+		type celsius float64
+		_, passed := ShouldBeWithin(0.01)([]celsius{20}, []celsius{20.005})
+		Wish(t, passed, ShouldEqual, true)
+		msg, _ := ShouldBeWithin(0.01)(celsius(20), celsius(21))
+		shouldStringMatch(t, msg, Dedent(`
+			root: differs by 1; tolerance is 0.01
+				- 20
+				+ 21
+		`))
+	})
+}
+
+func TestShouldBeWithinDuration(t *testing.T) {
+	base := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	type event struct {
+		At   time.Time
+		Took time.Duration
+	}
+	_, passed := ShouldBeWithinDuration(time.Second)(event{base, time.Minute}, event{base.Add(500 * time.Millisecond), time.Minute - time.Second})
+	Wish(t, passed, ShouldEqual, true)
+	msg, _ := ShouldBeWithinDuration(time.Second)(event{base, time.Minute}, event{base.Add(3 * time.Second), time.Minute})
+	shouldStringMatch(t, msg, Dedent(`
+		root.At: differs by 3s; tolerance is 1s
+			- time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+			+ time.Date(2020, time.January, 1, 0, 0, 3, 0, time.UTC)
+	`))
+
+	// Types defined as time.Duration can't be told apart from other int64
+	// types, like IDs, so only the time-like struct type is approximated.
+	type userID int64
+	type timeout time.Duration
+	type stamp time.Time
+	type job struct {
+		Owner   userID
+		Timeout timeout
+		Started stamp
+	}
+	_, passed = ShouldBeWithinDuration(time.Second)(job{1, timeout(time.Minute), stamp(base)}, job{1, timeout(time.Minute), stamp(base.Add(time.Millisecond))})
+	Wish(t, passed, ShouldEqual, true)
+	msg, _ = ShouldBeWithinDuration(time.Second)(job{1, timeout(time.Minute), stamp(base)}, job{500000000, timeout(time.Minute + time.Millisecond), stamp(base)})
+	shouldStringMatch(t, msg, Dedent(`
+		root.Owner:
+			- 1
+			+ 500000000
+		root.Timeout:
+			- 60000000000
+			+ 60001000000
+	`))
+}
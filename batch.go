@@ -0,0 +1,125 @@
+package wish
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// cleanuper is implemented by `*testing.T` (since go1.14).
+// Like deadliner, it's not part of the T interface, but is used if present.
+type cleanuper interface {
+	Cleanup(func())
+}
+
+// Batcher collects the rejections of many checks, so they can be reported
+// together in one consolidated message, rather than scattered through
+// the test output.  Create one with Batch.
+//
+// A Batcher is safe to use from multiple goroutines.
+type Batcher struct {
+	t    T
+	halt bool
+
+	mu         sync.Mutex
+	checks     int
+	rejections []string
+	done       bool
+}
+
+// Batch returns a new Batcher, which collects rejections for T.
+//
+// Call Wish on the Batcher as many times as needed, then call Done to log
+// the report.  If T supports Cleanup (as `*testing.T` does),
+// Done is also called automatically when the test finishes,
+// so the report is never lost if you forget.
+//
+// Each rejected check marks the test as failed immediately (as Wish does),
+// but logs nothing until Done.
+func Batch(t T) *Batcher {
+	t.Helper()
+	b := &Batcher{t: t}
+	if c, ok := t.(cleanuper); ok {
+		c.Cleanup(b.finish)
+	}
+	return b
+}
+
+// Halting configures the Batcher so that Done halts execution via T.FailNow
+// if any check was rejected (similar to Require).  It returns the Batcher,
+// for chaining: `b := wish.Batch(t).Halting()`.
+func (b *Batcher) Halting() *Batcher {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.halt = true
+	return b
+}
+
+// Wish makes an assertion like the package-level Wish function does,
+// except that if the check is rejected, the rejection message is saved
+// for the report made by Done, rather than logged immediately.
+//
+// Returns whether the check passed.
+func (b *Batcher) Wish(actual interface{}, check Checker, desired interface{}, opts ...Option) bool {
+	b.t.Helper()
+	site := here(argsOfBatcher)
+	cfg := buildConfig(opts)
+	problemMsg, passed := cfg.configure(check)(actual, desired)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.done {
+		panic("wish: Batcher.Wish called after Done")
+	}
+	b.checks++
+	if passed {
+		return true
+	}
+	headline := fmt.Sprintf("#%d %s%s check rejected", b.checks, getCheckerShortName(check), site.describe())
+	if file, line, ok := site.position(); ok {
+		headline += fmt.Sprintf(" at %s:%d", filepath.Base(file), line)
+	}
+	b.rejections = append(b.rejections, withTrailingNewline(cfg.formatRejection(headline, problemMsg)))
+	recordRejection(site, b.t, getCheckerShortName(check), actual, desired, problemMsg, cfg, false)
+	b.t.Fail()
+	return false
+}
+
+// Done logs a single report of all the rejections collected so far (if any),
+// numbered by the order in which their checks were made.
+// If the Batcher is Halting and any check was rejected, Done calls T.FailNow.
+//
+// Returns whether all checks passed.  Further calls to Done do nothing
+// (and return the same result).
+func (b *Batcher) Done() bool {
+	b.t.Helper()
+	passed := b.report()
+	if !passed && b.halt {
+		b.t.FailNow()
+	}
+	return passed
+}
+
+// finish is used for automatic cleanup.  It's the same as Done, except
+// it never halts, since the test is already over.
+func (b *Batcher) finish() {
+	b.t.Helper()
+	b.report()
+}
+
+func (b *Batcher) report() bool {
+	b.t.Helper()
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.done {
+		return len(b.rejections) == 0
+	}
+	b.done = true
+	if len(b.rejections) == 0 {
+		return true
+	}
+	b.t.Log(fmt.Sprintf("%d of %d checks in batch rejected:\n%s",
+		len(b.rejections), b.checks, strings.Join(b.rejections, "")))
+	return false
+}
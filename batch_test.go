@@ -0,0 +1,65 @@
+package wish
+
+import (
+	"testing"
+)
+
+// cleanupT is a recordingT which supports Cleanup.
+type cleanupT struct {
+	recordingT
+	cleanups []func()
+}
+
+func (t *cleanupT) Cleanup(fn func()) { t.cleanups = append(t.cleanups, fn) }
+
+func TestBatch(t *testing.T) {
+	t.Run("collects rejections into one report", func(t *testing.T) {
+		rt := &recordingT{}
+		b := Batch(rt)
+		b.Wish("a", ShouldEqual, "a")
+		b.Wish("b", ShouldEqual, "c")
+		b.Wish(1, ShouldEqual, 1, Messagef("fine"))
+		b.Wish(4, ShouldEqual, 5, Messagef("row %d", 4))
+		Wish(t, rt.failed, ShouldEqual, true)
+		Wish(t, rt.output(), ShouldEqual, "")
+		Wish(t, b.Done(), ShouldEqual, false)
+		Wish(t, rt.halted, ShouldEqual, false)
+		Wish(t, rt.output(), ShouldMatchPattern, Dedent(`
+			2 of 4 checks in batch rejected:
+			#2 ShouldEqual check rejected at batch_test.go:{{\d+}}:
+				@@ -1 +1 @@
+				- b
+				+ c
+			#4 ShouldEqual check rejected at batch_test.go:{{\d+}} (row 4):
+				  int(
+				- 	4,
+				+ 	5,
+				  )
+		`))
+	})
+	t.Run("all passing", func(t *testing.T) {
+		rt := &recordingT{}
+		b := Batch(rt).Halting()
+		b.Wish("a", ShouldEqual, "a")
+		Wish(t, b.Done(), ShouldEqual, true)
+		Wish(t, rt.failed, ShouldEqual, false)
+		Wish(t, rt.output(), ShouldEqual, "")
+	})
+	t.Run("halting", func(t *testing.T) {
+		rt := &recordingT{}
+		b := Batch(rt).Halting()
+		b.Wish("a", ShouldEqual, "b")
+		b.Done()
+		Wish(t, rt.halted, ShouldEqual, true)
+	})
+	t.Run("reports on cleanup", func(t *testing.T) {
+		rt := &cleanupT{}
+		b := Batch(rt)
+		b.Wish("a", ShouldEqual, "b")
+		Require(t, len(rt.cleanups), ShouldEqual, 1)
+		rt.cleanups[0]()
+		Wish(t, rt.output(), ShouldMatchPattern, "1 of 1 checks in batch rejected:\n...")
+		b.Done()
+		Wish(t, len(rt.logs), ShouldEqual, 1)
+	})
+}
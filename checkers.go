@@ -1,6 +1,7 @@
 package wish
 
 import (
+	"fmt"
 	"reflect"
 
 	"github.com/warpfork/go-wish/cmp"
@@ -18,42 +19,150 @@ var (
 // thus can be used to explicitly check pointer equality.
 //
 // For pointers, ShouldBe checks pointer equality.
+// If the pointers differ, the rejection message shows both addresses,
+// and also says whether the values they point to are equal (in which case
+// ShouldEqual would have passed).
+// For channels, ShouldBe checks that both values are the same channel.
+// Funcs are only comparable to nil, so ShouldBe rejects any non-nil func desire.
+//
+// A nil desire is accepted, and passes if the actual value is either
+// untyped nil or a nil pointer, channel, func, map, or slice.
+//
 // Using ShouldBe on any kind of values which require recursion to meaningfully
 // compare (e.g., structs, maps, arrays) will be rejected, as will using
 // ShouldBe on any kind of value which is already never recursive (e.g. any
-// primitives) since you can already use ShouldEqual to compare these.
+// primitives, including strings) since you can already use ShouldEqual to
+// compare these.
 func ShouldBe(actual interface{}, desire interface{}) (problem string, passed bool) {
+	if desire == nil {
+		if isNil(actual) {
+			return "", true
+		}
+		return fmt.Sprintf("got %s; wanted nil", describeIdentity(reflect.ValueOf(actual))), false
+	}
 	switch reflect.TypeOf(desire).Kind() {
 	case // primitives
 		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
 		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
-		reflect.Bool, reflect.Float32, reflect.Float64, reflect.Complex64, reflect.Complex128, reflect.UnsafePointer:
+		reflect.Bool, reflect.Float32, reflect.Float64, reflect.Complex64, reflect.Complex128, reflect.UnsafePointer,
+		reflect.String:
 		panic("use ShouldEqual instead of ShouldBe when comparing primitives")
 	case // recursives
 		reflect.Interface, reflect.Array, reflect.Map, reflect.Slice, reflect.Struct:
 		panic("use ShouldEqual instead of ShouldBe when comparing recursive values")
 	case reflect.Ptr:
-		panic("TODO")
+		if problem, ok := ShouldBeSameTypeAs(actual, desire); !ok {
+			return problem, false
+		}
+		rv_actual, rv_desire := reflect.ValueOf(actual), reflect.ValueOf(desire)
+		if rv_actual.Pointer() == rv_desire.Pointer() {
+			return "", true
+		}
+		problem = fmt.Sprintf("pointers are not identical:\n\tactual: %s\n\tdesire: %s\n",
+			describeIdentity(rv_actual),
+			describeIdentity(rv_desire),
+		)
+		switch {
+		case rv_actual.IsNil() || rv_desire.IsNil():
+			// Nothing more to say; one side points to nothing.
+		case cmp.Equal(rv_actual.Elem().Interface(), rv_desire.Elem().Interface()):
+			problem += "the values pointed to are equal (ShouldEqual would pass)\n"
+		default:
+			problem += "the values pointed to also differ\n"
+		}
+		return problem, false
 	case reflect.Func:
-		panic("TODO")
+		if !reflect.ValueOf(desire).IsNil() {
+			return "funcs are only comparable to nil; wanted a func value, which can never be matched", false
+		}
+		if problem, ok := ShouldBeSameTypeAs(actual, desire); !ok {
+			return problem, false
+		}
+		if reflect.ValueOf(actual).IsNil() {
+			return "", true
+		}
+		return fmt.Sprintf("got %s; wanted nil", describeIdentity(reflect.ValueOf(actual))), false
 	case reflect.Chan:
-		panic("TODO")
+		if problem, ok := ShouldBeSameTypeAs(actual, desire); !ok {
+			return problem, false
+		}
+		rv_actual, rv_desire := reflect.ValueOf(actual), reflect.ValueOf(desire)
+		if rv_actual.Pointer() == rv_desire.Pointer() {
+			return "", true
+		}
+		return fmt.Sprintf("channels are not identical:\n\tactual: %s\n\tdesire: %s\n",
+			describeIdentity(rv_actual),
+			describeIdentity(rv_desire),
+		), false
 	default:
 		panic("unknown kind")
 	}
 }
 
+// isNil reports whether v is untyped nil, or a nil value of a kind that
+// can be compared to nil.  (Interfaces don't show up here, since
+// storing a value in an interface{} already unwraps them.)
+func isNil(v interface{}) bool {
+	if v == nil {
+		return true
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Ptr, reflect.Chan, reflect.Func, reflect.Map, reflect.Slice:
+		return rv.IsNil()
+	default:
+		return false
+	}
+}
+
+// describeIdentity renders a value by its type and address, for those kinds
+// which have an address; other values are rendered with their type and
+// contents.
+func describeIdentity(rv reflect.Value) string {
+	if !rv.IsValid() {
+		return "untyped nil"
+	}
+	switch rv.Kind() {
+	case reflect.Ptr, reflect.Chan, reflect.Func, reflect.Map, reflect.Slice, reflect.UnsafePointer:
+		if rv.IsNil() {
+			return fmt.Sprintf("(%s)(nil)", rv.Type())
+		}
+		return fmt.Sprintf("(%s)(%#x)", rv.Type(), rv.Pointer())
+	default:
+		return fmt.Sprintf("%s(%#v)", rv.Type(), rv.Interface())
+	}
+}
+
 // ShouldEqual asserts that two values are the same, examining the values
 // recursively as necessary.  Maps, slices, and structs are all
 // valid to compare with ShouldEqual.  Pointers will be traversed, and
 // comparison continues with the values referenced by the pointer.
+//
+// ShouldEqual accepts the CmpOptions, ContextLines, DiffAlgorithm, and NDiff
+// options, as well as the NormalizeLineEndings, TrimTrailingSpace,
+// NormalizeUnicode, and NormalizeWith options, which adjust strings before
+// they're compared.
 func ShouldEqual(actual interface{}, desire interface{}) (diff string, eq bool) {
+	return shouldEqual(defaultConfig(), actual, desire)
+}
+
+func shouldEqual(cfg *config, actual interface{}, desire interface{}) (diff string, eq bool) {
 	s1, ok1 := actual.(string)
 	s2, ok2 := desire.(string)
 	if ok1 && ok2 {
-		diff = strdiff(s1, s2)
-	} else {
-		diff = cmp.Diff(actual, desire)
+		diff = cfg.strdiff(cfg.normalize(s1), cfg.normalize(s2))
+		if diff != "" && len(cfg.normalizations) > 0 {
+			diff = cfg.describeNormalizations(s1, s2) + diff
+		}
+		return diff, diff == ""
+	}
+	opts := cfg.cmpOpts
+	if len(cfg.normalizations) > 0 {
+		opts = append(opts[:len(opts):len(opts)], cfg.normalizingCmpOption())
+	}
+	diff = cmp.Diff(actual, desire, opts...)
+	if diff != "" && len(cfg.normalizations) > 0 {
+		diff = cfg.normalizationNames() + " in all strings:\n" + diff
 	}
 	return diff, diff == ""
 }
@@ -69,6 +178,7 @@ func ShouldEqual(actual interface{}, desire interface{}) (diff string, eq bool)
 // ShouldBeSameTypeAs followed by a Wish of ShouldEqual on the err.Error string
 // is often a good combination of clear and terse and good coverage -- and
 // nicely handles and reports an unexpectedly nil value of the error, as well.)
+// If your errors are wrapped, see ShouldWrap and ShouldBeErrorAs instead.
 func ShouldBeSameTypeAs(actual interface{}, desire interface{}) (diff string, eq bool) {
 	rt_desire := reflect.ValueOf(desire).Type()
 	if actual == nil {
@@ -58,7 +58,9 @@ func TestShouldEqual(t *testing.T) {
 		), Dedent(`
 			@@ -1 +1 @@
 			- asdf
+			?    ^
 			+ asdx
+			?    ^
 		`))
 	})
 	t.Run("fmt.Errorf errors", func(t *testing.T) {
@@ -91,3 +93,79 @@ func TestShouldEqual(t *testing.T) {
 		})
 	})
 }
+
+func TestShouldBe(t *testing.T) {
+	shouldBe := func(a, d interface{}) string {
+		msg, _ := ShouldBe(a, d)
+		return msg
+	}
+	type thing struct{ x int }
+	t.Run("identical pointers", func(t *testing.T) {
+		p := &thing{1}
+		shouldStringMatch(t, shouldBe(p, p), "")
+	})
+	t.Run("distinct pointers to equal values", func(t *testing.T) {
+		p1, p2 := &thing{1}, &thing{1}
+		shouldStringMatch(t, shouldBe(p1, p2), fmt.Sprintf(Dedent(`
+			pointers are not identical:
+				actual: (*wish.thing)(%p)
+				desire: (*wish.thing)(%p)
+			the values pointed to are equal (ShouldEqual would pass)
+		`), p1, p2))
+	})
+	t.Run("distinct pointers to distinct values", func(t *testing.T) {
+		p1, p2 := &thing{1}, &thing{2}
+		shouldStringMatch(t, shouldBe(p1, p2), fmt.Sprintf(Dedent(`
+			pointers are not identical:
+				actual: (*wish.thing)(%p)
+				desire: (*wish.thing)(%p)
+			the values pointed to also differ
+		`), p1, p2))
+	})
+	t.Run("pointer compared to nil pointer", func(t *testing.T) {
+		p := &thing{1}
+		shouldStringMatch(t, shouldBe(p, (*thing)(nil)), fmt.Sprintf(Dedent(`
+			pointers are not identical:
+				actual: (*wish.thing)(%p)
+				desire: (*wish.thing)(nil)
+		`), p))
+	})
+	t.Run("pointers of distinct types", func(t *testing.T) {
+		shouldStringMatch(t, shouldBe(&thing{}, new(int)),
+			"got value of type *wish.thing; wanted a value of type *int")
+	})
+	t.Run("channels", func(t *testing.T) {
+		ch1, ch2 := make(chan int), make(chan int)
+		shouldStringMatch(t, shouldBe(ch1, ch1), "")
+		shouldStringMatch(t, shouldBe(ch1, ch2), fmt.Sprintf(Dedent(`
+			channels are not identical:
+				actual: (chan int)(%p)
+				desire: (chan int)(%p)
+		`), ch1, ch2))
+	})
+	t.Run("funcs", func(t *testing.T) {
+		fn := func() {}
+		shouldStringMatch(t, shouldBe((func())(nil), (func())(nil)), "")
+		shouldStringMatch(t, shouldBe(fn, fn),
+			"funcs are only comparable to nil; wanted a func value, which can never be matched")
+		shouldStringMatch(t, shouldBe(fn, (func())(nil)), fmt.Sprintf(
+			"got (func())(%p); wanted nil", fn))
+	})
+	t.Run("nil desire", func(t *testing.T) {
+		shouldStringMatch(t, shouldBe(nil, nil), "")
+		shouldStringMatch(t, shouldBe((*thing)(nil), nil), "")
+		ptr := &thing{}
+		shouldStringMatch(t, shouldBe(ptr, nil), fmt.Sprintf("got (*wish.thing)(%p); wanted nil", ptr))
+		shouldStringMatch(t, shouldBe("str", nil), `got string("str"); wanted nil`)
+	})
+	t.Run("primitives", func(t *testing.T) {
+		for _, v := range []interface{}{1, 1.5, true, "str"} {
+			func() {
+				defer func() {
+					shouldStringMatch(t, recover().(string), "use ShouldEqual instead of ShouldBe when comparing primitives")
+				}()
+				ShouldBe(v, v)
+			}()
+		}
+	})
+}
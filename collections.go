@@ -0,0 +1,314 @@
+package wish
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/warpfork/go-wish/cmp"
+)
+
+var (
+	_ Checker = ShouldContain
+	_ Checker = ShouldContainKey
+	_ Checker = ShouldHaveLength
+	_ Checker = ShouldBeIn
+	_ Checker = ShouldEqualUnordered
+)
+
+// ShouldContain asserts that the actual value is a collection which contains
+// the desired value.
+//
+// For slices and arrays, some element must be equal to the desire;
+// for maps, some value (not key; see ShouldContainKey for that) must be.
+// Elements are compared with the same semantics as ShouldEqual.
+// If the desire is itself a slice or array of the same type as the actual
+// value, every one of its elements must be present.
+// For strings, the desire must also be a string, and must be a substring.
+//
+// If an element is missing, the rejection message shows a diff against the
+// nearest candidate in the collection: that's the element with the fewest
+// differing fields, which is usually the one you meant.
+func ShouldContain(actual interface{}, desire interface{}) (problem string, passed bool) {
+	if s, ok := actual.(string); ok {
+		sub, ok := desire.(string)
+		if !ok {
+			panic("ShouldContain on a string must be used with a string as the desired value")
+		}
+		if strings.Contains(s, sub) {
+			return "", true
+		}
+		return fmt.Sprintf("string %q does not contain %q", s, sub), false
+	}
+	haystack, problem := collectionMembers(actual)
+	if problem != "" {
+		return problem, false
+	}
+	rv_desire := reflect.ValueOf(desire)
+	switch rv_desire.Kind() {
+	case reflect.Slice, reflect.Array:
+		if rv_desire.Type() != reflect.TypeOf(actual) {
+			break
+		}
+		var sb strings.Builder
+		missing := 0
+		for i := 0; i < rv_desire.Len(); i++ {
+			if problem, passed := findMember(haystack, rv_desire.Index(i).Interface(), false); !passed {
+				missing++
+				fmt.Fprintf(&sb, "desired element [%d] was not found; %s", i, problem)
+			}
+		}
+		if missing == 0 {
+			return "", true
+		}
+		return fmt.Sprintf("%d of %d desired elements were not found:\n%s", missing, rv_desire.Len(), sb.String()), false
+	}
+	if problem, passed := findMember(haystack, desire, false); !passed {
+		return "the desired element was not found; " + problem, false
+	}
+	return "", true
+}
+
+// ShouldContainKey asserts that the actual value is a map which contains the
+// desired value as a key.
+//
+// If the key is missing, the rejection message shows a diff against the
+// nearest key which is present.
+func ShouldContainKey(actual interface{}, desire interface{}) (problem string, passed bool) {
+	rv := reflect.ValueOf(actual)
+	if rv.Kind() != reflect.Map {
+		return fmt.Sprintf("got value of type %T, which is not a map", actual), false
+	}
+	rv_key := reflect.ValueOf(desire)
+	if !rv_key.IsValid() || !rv_key.Type().AssignableTo(rv.Type().Key()) {
+		return fmt.Sprintf("map has keys of type %s; wanted a key of type %T", rv.Type().Key(), desire), false
+	}
+	if rv.MapIndex(rv_key).IsValid() {
+		return "", true
+	}
+	keys := make([]collectionMember, 0, rv.Len())
+	for _, k := range rv.MapKeys() {
+		keys = append(keys, collectionMember{fmt.Sprintf("%#v", k.Interface()), k.Interface()})
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i].label < keys[j].label })
+	problem, _ = findMember(keys, desire, false)
+	return "the desired key was not found; " + problem, false
+}
+
+// ShouldHaveLength asserts that the actual value is a slice, array, map,
+// string, or channel, and has the length given by the desire (an int).
+func ShouldHaveLength(actual interface{}, desire interface{}) (problem string, passed bool) {
+	length, ok := desire.(int)
+	if !ok {
+		panic("ShouldHaveLength must be used with an int as the desired value")
+	}
+	rv := reflect.ValueOf(actual)
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map, reflect.String, reflect.Chan:
+		if rv.Len() == length {
+			return "", true
+		}
+		return fmt.Sprintf("got length %d; wanted %d", rv.Len(), length), false
+	case reflect.Invalid:
+		return "got untyped nil, which has no length", false
+	default:
+		return fmt.Sprintf("got value of type %T, which has no length", actual), false
+	}
+}
+
+// ShouldBeIn asserts that the actual value is one of the elements of the
+// collection given as the desire.  It is the reverse of ShouldContain:
+// the desire must be a slice, array, or map (whose values are searched),
+// or a string (of which the actual value must be a substring).
+func ShouldBeIn(actual interface{}, desire interface{}) (problem string, passed bool) {
+	if s, ok := desire.(string); ok {
+		sub, ok := actual.(string)
+		if !ok {
+			return fmt.Sprintf("got value of type %T; wanted a substring of %q", actual, s), false
+		}
+		if strings.Contains(s, sub) {
+			return "", true
+		}
+		return fmt.Sprintf("%q is not a substring of %q", sub, s), false
+	}
+	haystack, problem := collectionMembers(desire)
+	if problem != "" {
+		panic("ShouldBeIn must be used with a slice, array, map, or string as the desired value")
+	}
+	if problem, passed := findMember(haystack, actual, true); !passed {
+		return "the actual value is not in the desired collection; " + problem, false
+	}
+	return "", true
+}
+
+// ShouldEqualUnordered asserts that two slices (or arrays) contain the same
+// elements, ignoring their order: each element of the actual value must be
+// paired with an equal element of the desire, and vice versa.
+// Duplicates count, so this has the semantics of comparing multisets.
+// Elements are compared with the same semantics as ShouldEqual,
+// and need not be sortable, nor even comparable with `==`.
+//
+// The pairing is the best possible one (a maximum bipartite matching),
+// so if the check is rejected, the rejection message lists only the elements
+// on each side that truly have no partner.
+//
+// ShouldEqualUnordered accepts the CmpOptions option.
+func ShouldEqualUnordered(actual interface{}, desire interface{}) (problem string, passed bool) {
+	return shouldEqualUnordered(defaultConfig(), actual, desire)
+}
+
+func shouldEqualUnordered(cfg *config, actual interface{}, desire interface{}) (problem string, passed bool) {
+	rv_desire := reflect.ValueOf(desire)
+	if k := rv_desire.Kind(); k != reflect.Slice && k != reflect.Array {
+		panic("ShouldEqualUnordered must be used with a slice or array as the desired value")
+	}
+	if problem, ok := ShouldBeSameTypeAs(actual, desire); !ok {
+		return problem, false
+	}
+	rv_actual := reflect.ValueOf(actual)
+	nActual, nDesire := rv_actual.Len(), rv_desire.Len()
+
+	// Compute all the pairwise equalities up front; each will be needed
+	// at least once, and the matching may revisit them many times.
+	equal := make([][]bool, nActual)
+	for i := range equal {
+		equal[i] = make([]bool, nDesire)
+		for j := range equal[i] {
+			equal[i][j] = cmp.Equal(rv_actual.Index(i).Interface(), rv_desire.Index(j).Interface(), cfg.cmpOpts...)
+		}
+	}
+
+	// Find a maximum matching with augmenting paths (Kuhn's algorithm):
+	// for each actual element, try to claim a desired element, bumping
+	// an earlier claimant to another partner if it has one available.
+	partnerOfDesire := make([]int, nDesire)
+	for j := range partnerOfDesire {
+		partnerOfDesire[j] = -1
+	}
+	var augment func(i int, visited []bool) bool
+	augment = func(i int, visited []bool) bool {
+		for j := 0; j < nDesire; j++ {
+			if !equal[i][j] || visited[j] {
+				continue
+			}
+			visited[j] = true
+			if partnerOfDesire[j] < 0 || augment(partnerOfDesire[j], visited) {
+				partnerOfDesire[j] = i
+				return true
+			}
+		}
+		return false
+	}
+	matchedActual := make([]bool, nActual)
+	for i := 0; i < nActual; i++ {
+		matchedActual[i] = augment(i, make([]bool, nDesire))
+	}
+
+	var sb strings.Builder
+	var unmatchedActual int
+	for i, matched := range matchedActual {
+		if !matched {
+			if unmatchedActual == 0 {
+				sb.WriteString("actual elements with no match:\n")
+			}
+			unmatchedActual++
+			fmt.Fprintf(&sb, "\t[%d]: %#v\n", i, rv_actual.Index(i).Interface())
+		}
+	}
+	var unmatchedDesire int
+	for j, partner := range partnerOfDesire {
+		if partner < 0 {
+			if unmatchedDesire == 0 {
+				sb.WriteString("desired elements with no match:\n")
+			}
+			unmatchedDesire++
+			fmt.Fprintf(&sb, "\t[%d]: %#v\n", j, rv_desire.Index(j).Interface())
+		}
+	}
+	if unmatchedActual+unmatchedDesire == 0 {
+		return "", true
+	}
+	return fmt.Sprintf("%d of %d actual and %d of %d desired elements could not be paired:\n%s", unmatchedActual, nActual, unmatchedDesire, nDesire, sb.String()), false
+}
+
+// collectionMember is one element of a collection, labeled by how to find
+// it (e.g. "[4]" for a slice, `["key"]` for a map).
+type collectionMember struct {
+	label string
+	value interface{}
+}
+
+// collectionMembers lists the elements of a slice or array, or the values of
+// a map (ordered by key, so that output is stable).
+// A problem description is returned if the value isn't a collection.
+func collectionMembers(collection interface{}) ([]collectionMember, string) {
+	rv := reflect.ValueOf(collection)
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array:
+		members := make([]collectionMember, rv.Len())
+		for i := range members {
+			members[i] = collectionMember{fmt.Sprintf("[%d]", i), rv.Index(i).Interface()}
+		}
+		return members, ""
+	case reflect.Map:
+		members := make([]collectionMember, 0, rv.Len())
+		for _, k := range rv.MapKeys() {
+			members = append(members, collectionMember{fmt.Sprintf("[%#v]", k.Interface()), rv.MapIndex(k).Interface()})
+		}
+		sort.Slice(members, func(i, j int) bool { return members[i].label < members[j].label })
+		return members, ""
+	case reflect.Invalid:
+		return nil, "got untyped nil, which is not a collection"
+	default:
+		return nil, fmt.Sprintf("got value of type %T, which is not a collection", collection)
+	}
+}
+
+// findMember looks for a member equal to the wanted value.  If none is equal,
+// the problem description includes a diff against the nearest member.
+// The diff is written with the member as the "actual" side,
+// unless reversed is set.
+func findMember(members []collectionMember, want interface{}, reversed bool) (problem string, passed bool) {
+	if len(members) == 0 {
+		return "the collection is empty\n", false
+	}
+	nearest, nearestScore := 0, -1
+	for i, m := range members {
+		score := countDifferences(m.value, want)
+		if score == 0 {
+			return "", true
+		}
+		if nearestScore < 0 || score < nearestScore {
+			nearest, nearestScore = i, score
+		}
+	}
+	var diff string
+	if reversed {
+		diff, _ = ShouldEqual(want, members[nearest].value)
+	} else {
+		diff, _ = ShouldEqual(members[nearest].value, want)
+	}
+	return fmt.Sprintf("the nearest of %d candidates is %s:\n%s", len(members), members[nearest].label, Indent(withTrailingNewline(diff))), false
+}
+
+// countDifferences compares two values the same way cmp.Equal does,
+// and returns how many leaves of the value tree were unequal.
+func countDifferences(x, y interface{}) int {
+	var counter differenceCounter
+	cmp.Equal(x, y, cmp.Reporter(&counter))
+	return counter.n
+}
+
+// differenceCounter is a cmp.Reporter that counts unequal leaves.
+type differenceCounter struct {
+	n int
+}
+
+func (*differenceCounter) PushStep(cmp.PathStep) {}
+func (*differenceCounter) PopStep()              {}
+func (c *differenceCounter) Report(r cmp.Result) {
+	if !r.Equal() {
+		c.n++
+	}
+}
@@ -0,0 +1,138 @@
+package wish
+
+import (
+	"testing"
+)
+
+type point struct {
+	X, Y int
+	Name string
+}
+
+func TestShouldContain(t *testing.T) {
+	pts := []point{{1, 2, "a"}, {3, 4, "b"}, {5, 6, "c"}}
+	t.Run("element present", func(t *testing.T) {
+		_, passed := ShouldContain(pts, point{3, 4, "b"})
+		Wish(t, passed, ShouldEqual, true)
+	})
+	t.Run("element missing", func(t *testing.T) {
+		msg, passed := ShouldContain(pts, point{3, 4, "z"})
+		Wish(t, passed, ShouldEqual, false)
+		shouldStringMatch(t, msg, Dedent(`
+			the desired element was not found; the nearest of 3 candidates is [1]:
+				  wish.point{
+				  	X:    3,
+				  	Y:    4,
+				- 	Name: "b",
+				+ 	Name: "z",
+				  }
+		`))
+	})
+	t.Run("several elements", func(t *testing.T) {
+		msg, passed := ShouldContain(pts, []point{{5, 6, "c"}, {1, 9, "a"}})
+		Wish(t, passed, ShouldEqual, false)
+		shouldStringMatch(t, msg, Dedent(`
+			1 of 2 desired elements were not found:
+			desired element [1] was not found; the nearest of 3 candidates is [0]:
+				  wish.point{
+				  	X:    1,
+				- 	Y:    2,
+				+ 	Y:    9,
+				  	Name: "a",
+				  }
+		`))
+	})
+	t.Run("map values", func(t *testing.T) {
+		_, passed := ShouldContain(map[string]int{"a": 1, "b": 2}, 2)
+		Wish(t, passed, ShouldEqual, true)
+	})
+	t.Run("substring", func(t *testing.T) {
+		_, passed := ShouldContain("foobar", "oba")
+		Wish(t, passed, ShouldEqual, true)
+		msg, _ := ShouldContain("foobar", "baz")
+		shouldStringMatch(t, msg, `string "foobar" does not contain "baz"`)
+	})
+	t.Run("empty", func(t *testing.T) {
+		msg, _ := ShouldContain([]int{}, 1)
+		shouldStringMatch(t, msg, "the desired element was not found; the collection is empty\n")
+	})
+	t.Run("not a collection", func(t *testing.T) {
+		msg, _ := ShouldContain(4, 1)
+		shouldStringMatch(t, msg, "got value of type int, which is not a collection")
+	})
+}
+
+func TestShouldContainKey(t *testing.T) {
+	m := map[string]int{"alpha": 1, "beta": 2}
+	_, passed := ShouldContainKey(m, "beta")
+	Wish(t, passed, ShouldEqual, true)
+	msg, _ := ShouldContainKey(m, "bata")
+	shouldStringMatch(t, msg, Dedent(`
+		the desired key was not found; the nearest of 2 candidates is "alpha":
+			@@ -1 +1 @@
+			- alpha
+			+ bata
+	`))
+	msg, _ = ShouldContainKey(m, 4)
+	shouldStringMatch(t, msg, "map has keys of type string; wanted a key of type int")
+}
+
+func TestShouldHaveLength(t *testing.T) {
+	_, passed := ShouldHaveLength([]int{1, 2}, 2)
+	Wish(t, passed, ShouldEqual, true)
+	msg, _ := ShouldHaveLength(map[int]int{}, 2)
+	shouldStringMatch(t, msg, "got length 0; wanted 2")
+	msg, _ = ShouldHaveLength(4, 2)
+	shouldStringMatch(t, msg, "got value of type int, which has no length")
+}
+
+func TestShouldBeIn(t *testing.T) {
+	_, passed := ShouldBeIn("b", []string{"a", "b"})
+	Wish(t, passed, ShouldEqual, true)
+	msg, _ := ShouldBeIn(point{1, 2, "q"}, []point{{1, 2, "a"}, {3, 4, "b"}})
+	shouldStringMatch(t, msg, Dedent(`
+		the actual value is not in the desired collection; the nearest of 2 candidates is [0]:
+			  wish.point{
+			  	X:    1,
+			  	Y:    2,
+			- 	Name: "q",
+			+ 	Name: "a",
+			  }
+	`))
+}
+
+func TestShouldEqualUnordered(t *testing.T) {
+	t.Run("same elements in another order", func(t *testing.T) {
+		_, passed := ShouldEqualUnordered(
+			[]point{{1, 2, "a"}, {3, 4, "b"}, {1, 2, "a"}},
+			[]point{{1, 2, "a"}, {1, 2, "a"}, {3, 4, "b"}},
+		)
+		Wish(t, passed, ShouldEqual, true)
+	})
+	t.Run("uncomparable elements", func(t *testing.T) {
+		_, passed := ShouldEqualUnordered(
+			[][]int{{1}, {2, 3}},
+			[][]int{{2, 3}, {1}},
+		)
+		Wish(t, passed, ShouldEqual, true)
+	})
+	t.Run("duplicates count", func(t *testing.T) {
+		msg, passed := ShouldEqualUnordered([]int{1, 1, 2}, []int{1, 2, 2})
+		Wish(t, passed, ShouldEqual, false)
+		shouldStringMatch(t, msg, Dedent(`
+			1 of 3 actual and 1 of 3 desired elements could not be paired:
+			actual elements with no match:
+				[1]: 1
+			desired elements with no match:
+				[2]: 2
+		`))
+	})
+	t.Run("lengths differ", func(t *testing.T) {
+		msg, _ := ShouldEqualUnordered([]string{"a", "b", "c"}, []string{"c", "a"})
+		shouldStringMatch(t, msg, Dedent(`
+			1 of 3 actual and 0 of 2 desired elements could not be paired:
+			actual elements with no match:
+				[1]: "b"
+		`))
+	})
+}
@@ -0,0 +1,151 @@
+package wish
+
+import (
+	"os"
+	"strings"
+	"sync"
+)
+
+// ColorEnvVar is the name of the environment variable which controls
+// whether rejection messages are colorized with ANSI escape codes.
+//
+// Its value may be "always", "never", or "auto" (the default).
+// In "auto" mode, color is used only if stdout is a terminal,
+// and the NO_COLOR variable is not set, and TERM is not "dumb".
+// (Note that when `go test` is given a list of packages, it collects the
+// output of the test binaries before printing it, so stdout won't be
+// a terminal; set this variable to "always" if you want color then.)
+//
+// The Colorize option overrides this for a single check.
+const ColorEnvVar = "WISH_COLOR"
+
+const (
+	ansiReset = "\x1b[0m"
+	ansiRed   = "\x1b[31m"
+	ansiGreen = "\x1b[32m"
+	ansiCyan  = "\x1b[36m"
+
+	ansiReverse    = "\x1b[7m"
+	ansiReverseOff = "\x1b[27m"
+)
+
+type colorMode int
+
+const (
+	colorAuto colorMode = iota
+	colorAlways
+	colorNever
+)
+
+// Colorize forces colored rejection messages on or off for this check,
+// regardless of ColorEnvVar and whether the output is a terminal.
+func Colorize(on bool) Option {
+	return optionFunc(func(cfg *config) {
+		if on {
+			cfg.color = colorAlways
+		} else {
+			cfg.color = colorNever
+		}
+	})
+}
+
+// useColor decides whether to colorize, given the config's mode.
+func (cfg *config) useColor() bool {
+	mode := cfg.color
+	if mode == colorAuto {
+		switch os.Getenv(ColorEnvVar) {
+		case "always":
+			mode = colorAlways
+		case "never":
+			mode = colorNever
+		}
+	}
+	switch mode {
+	case colorAlways:
+		return true
+	case colorNever:
+		return false
+	default:
+		return terminalSupportsColor()
+	}
+}
+
+var (
+	terminalColorOnce sync.Once
+	terminalColor     bool
+)
+
+// terminalSupportsColor reports whether stdout is a terminal that we should
+// send color to.  A regular file or pipe (which is what stdout is when
+// `go test` is buffering or saving output) is never colorized.
+func terminalSupportsColor() bool {
+	terminalColorOnce.Do(func() {
+		if _, ok := os.LookupEnv("NO_COLOR"); ok || os.Getenv("TERM") == "dumb" {
+			return
+		}
+		fi, err := os.Stdout.Stat()
+		terminalColor = err == nil && fi.Mode()&os.ModeCharDevice != 0
+	})
+	return terminalColor
+}
+
+// colorizeDiff adds ANSI color to each line of a diff: lines removed
+// ("- " prefix) are red, lines inserted ("+ " prefix) are green, and hunk
+// headers ("@@") are cyan.  Where a line is followed by an intraline guide
+// ("? " prefix), the characters the guide marks are also shown in reverse
+// video.  Other lines are unchanged.
+//
+// Colorizing only ever adds escape codes, so removing them gives back
+// exactly the original text.
+func colorizeDiff(s string) string {
+	lines := strings.SplitAfter(s, "\n")
+	var sb strings.Builder
+	for i, line := range lines {
+		body := strings.TrimSuffix(line, "\n")
+		var color string
+		switch {
+		case strings.HasPrefix(body, "- "):
+			color = ansiRed
+		case strings.HasPrefix(body, "+ "):
+			color = ansiGreen
+		case strings.HasPrefix(body, "@@"):
+			color = ansiCyan
+		}
+		if color == "" || body == "" {
+			sb.WriteString(line)
+			continue
+		}
+		sb.WriteString(color)
+		if color != ansiCyan && i+1 < len(lines) && strings.HasPrefix(lines[i+1], "? ") {
+			writeHighlighted(&sb, body, lines[i+1])
+		} else {
+			sb.WriteString(body)
+		}
+		sb.WriteString(ansiReset)
+		sb.WriteString(line[len(body):])
+	}
+	return sb.String()
+}
+
+// writeHighlighted writes the line, showing each rune which has a mark
+// ("^", or the "-" and "+" of difflib.NDiff) under it in the guide line
+// in reverse video.
+func writeHighlighted(sb *strings.Builder, line, guide string) {
+	marks := []rune(strings.TrimSuffix(guide, "\n"))
+	reversed := false
+	for k, r := range []rune(line) {
+		marked := k < len(marks) && (marks[k] == '^' || marks[k] == '-' || marks[k] == '+')
+		if marked != reversed {
+			if marked {
+				sb.WriteString(ansiReverse)
+			} else {
+				sb.WriteString(ansiReverseOff)
+			}
+			reversed = marked
+		}
+		sb.WriteRune(r)
+	}
+	if reversed {
+		sb.WriteString(ansiReverseOff)
+	}
+}
@@ -0,0 +1,52 @@
+package wish
+
+import (
+	"os"
+	"regexp"
+	"testing"
+)
+
+// TestMain disables color for this package's own tests, since many of them
+// compare exact rejection messages, and would otherwise fail when run
+// directly in a terminal.  Tests of color set ColorEnvVar themselves.
+func TestMain(m *testing.M) {
+	os.Setenv(ColorEnvVar, "never")
+	os.Exit(m.Run())
+}
+
+var ansiEscape = regexp.MustCompile("\x1b\\[[0-9;]*m")
+
+func TestColorizeDiff(t *testing.T) {
+	diff, _ := ShouldEqual("a\nb\nc", "a\nx\nc")
+	colored := colorizeDiff(diff)
+	shouldStringMatch(t, colored, "\x1b[36m@@ -1,3 +1,3 @@\x1b[0m\n"+
+		"  a\n"+
+		"\x1b[31m- b\x1b[0m\n"+
+		"\x1b[32m+ x\x1b[0m\n"+
+		"  c\n")
+	shouldStringMatch(t, ansiEscape.ReplaceAllString(colored, ""), diff)
+
+	diff, _ = ShouldEqual("the quick fox", "the quack fox")
+	colored = colorizeDiff(diff)
+	shouldStringMatch(t, colored, "\x1b[36m@@ -1 +1 @@\x1b[0m\n"+
+		"\x1b[31m- the qu\x1b[7mi\x1b[27mck fox\x1b[0m\n"+
+		"?       ^\n"+
+		"\x1b[32m+ the qu\x1b[7ma\x1b[27mck fox\x1b[0m\n"+
+		"?       ^\n")
+	shouldStringMatch(t, ansiEscape.ReplaceAllString(colored, ""), diff)
+}
+
+func TestColorOption(t *testing.T) {
+	t.Setenv(ColorEnvVar, "never")
+	rt := &recordingT{}
+	Wish(rt, "a", ShouldEqual, "b")
+	Wish(rt, "a", ShouldEqual, "b", Colorize(true))
+	Wish(t, ansiEscape.MatchString(rt.logs[0]), ShouldEqual, false)
+	Wish(t, ansiEscape.MatchString(rt.logs[1]), ShouldEqual, true)
+	Wish(t, ansiEscape.ReplaceAllString(rt.logs[1], ""), ShouldEqual, rt.logs[0])
+
+	t.Setenv(ColorEnvVar, "always")
+	rt = &recordingT{}
+	Wish(rt, "a", ShouldEqual, "b")
+	Wish(t, ansiEscape.MatchString(rt.logs[0]), ShouldEqual, true)
+}
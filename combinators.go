@@ -0,0 +1,104 @@
+package wish
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Not returns a Checker which passes when the given Checker rejects,
+// and rejects when the given Checker passes.
+//
+// For example, `Wish(t, x, Not(ShouldEqual), y)` asserts that x and y differ.
+func Not(check Checker) Checker {
+	return wrapChecker("Not("+getCheckerShortName(check)+")", func(cfg *config) (Checker, bool) {
+		inner, ok := cfg.tryConfigure(check)
+		if !ok {
+			return nil, false
+		}
+		return func(actual interface{}, desire interface{}) (problem string, passed bool) {
+			if _, passed := inner(actual, desire); passed {
+				return getCheckerShortName(check) + " check passed, but was expected to be rejected", false
+			}
+			return "", true
+		}, true
+	})
+}
+
+// AllOf returns a Checker which passes only if every one of the given
+// Checkers passes.
+//
+// All of the Checkers are always run (there's no short-circuiting), so
+// that the rejection message can report on each of them: which passed,
+// and the problem reported by each that rejected.
+func AllOf(checks ...Checker) Checker {
+	return wrapChecker("AllOf", func(cfg *config) (Checker, bool) {
+		configured, ok := cfg.tryConfigureAny(checks)
+		if !ok {
+			return nil, false
+		}
+		return func(actual interface{}, desire interface{}) (problem string, passed bool) {
+			results, nPassed := runAll(checks, configured, actual, desire)
+			if nPassed == len(checks) {
+				return "", true
+			}
+			return fmt.Sprintf("%d of %d checks rejected:\n%s", len(checks)-nPassed, len(checks), results), false
+		}, true
+	})
+}
+
+// AnyOf returns a Checker which passes if at least one of the given
+// Checkers passes.  An AnyOf with no Checkers always rejects.
+//
+// If no Checker passes, the rejection message includes the problem
+// reported by each one of them.
+func AnyOf(checks ...Checker) Checker {
+	return wrapChecker("AnyOf", func(cfg *config) (Checker, bool) {
+		configured, ok := cfg.tryConfigureAny(checks)
+		if !ok {
+			return nil, false
+		}
+		return func(actual interface{}, desire interface{}) (problem string, passed bool) {
+			results, nPassed := runAll(checks, configured, actual, desire)
+			if nPassed > 0 {
+				return "", true
+			}
+			return fmt.Sprintf("none of %d checks passed:\n%s", len(checks), results), false
+		}, true
+	})
+}
+
+// tryConfigureAny configures each of the checks which accepts the config,
+// leaving the others as they are.  It returns false if none accepts it.
+func (cfg *config) tryConfigureAny(checks []Checker) ([]Checker, bool) {
+	configured := make([]Checker, len(checks))
+	accepted := len(cfg.checkerSpecific) == 0
+	for i, check := range checks {
+		c, ok := cfg.tryConfigure(check)
+		if !ok {
+			c = check
+		}
+		configured[i] = c
+		accepted = accepted || ok
+	}
+	return configured, accepted
+}
+
+// runAll applies each check (in its configured form), and returns a summary
+// of all their outcomes (one line per passing check, and the indented problem
+// for each rejection), as well as the count of checks that passed.
+func runAll(checks, configured []Checker, actual interface{}, desire interface{}) (summary string, nPassed int) {
+	var sb strings.Builder
+	for i, check := range checks {
+		problem, passed := configured[i](actual, desire)
+		if passed {
+			nPassed++
+			fmt.Fprintf(&sb, "%s passed\n", getCheckerShortName(check))
+			continue
+		}
+		fmt.Fprintf(&sb, "%s rejected:\n%s", getCheckerShortName(check), Indent(problem))
+		if !strings.HasSuffix(problem, "\n") {
+			sb.WriteByte('\n')
+		}
+	}
+	return sb.String(), nPassed
+}
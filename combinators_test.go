@@ -0,0 +1,79 @@
+package wish
+
+import (
+	"testing"
+)
+
+func TestNot(t *testing.T) {
+	msg, passed := Not(ShouldEqual)(1, 2)
+	if !passed {
+		t.Errorf("Not(ShouldEqual) should pass for distinct values")
+	}
+	shouldStringMatch(t, msg, "")
+
+	msg, passed = Not(ShouldEqual)(1, 1)
+	if passed {
+		t.Errorf("Not(ShouldEqual) should reject equal values")
+	}
+	shouldStringMatch(t, msg, "ShouldEqual check passed, but was expected to be rejected")
+}
+
+func TestAllOf(t *testing.T) {
+	t.Run("all passing", func(t *testing.T) {
+		msg, passed := AllOf(ShouldBeSameTypeAs, ShouldEqual)("a", "a")
+		if !passed {
+			t.Errorf("should pass")
+		}
+		shouldStringMatch(t, msg, "")
+	})
+	t.Run("some rejecting", func(t *testing.T) {
+		msg, passed := AllOf(ShouldBeSameTypeAs, ShouldEqual, Not(ShouldBeSameTypeAs))("asdf", "asdx")
+		if passed {
+			t.Errorf("should reject")
+		}
+		shouldStringMatch(t, msg, Dedent(`
+			2 of 3 checks rejected:
+			ShouldBeSameTypeAs passed
+			ShouldEqual rejected:
+				@@ -1 +1 @@
+				- asdf
+				?    ^
+				+ asdx
+				?    ^
+			Not(ShouldBeSameTypeAs) rejected:
+				ShouldBeSameTypeAs check passed, but was expected to be rejected
+		`))
+	})
+}
+
+func TestAnyOf(t *testing.T) {
+	t.Run("one passing", func(t *testing.T) {
+		msg, passed := AnyOf(ShouldEqual, ShouldBeSameTypeAs)("asdf", "asdx")
+		if !passed {
+			t.Errorf("should pass")
+		}
+		shouldStringMatch(t, msg, "")
+	})
+	t.Run("none passing", func(t *testing.T) {
+		msg, passed := AnyOf(ShouldEqual, ShouldBeSameTypeAs)("asdf", 1)
+		if passed {
+			t.Errorf("should reject")
+		}
+		shouldStringMatch(t, msg, Dedent(`
+			none of 2 checks passed:
+			ShouldEqual rejected:
+				  interface{}(
+				- 	string("asdf"),
+				+ 	int(1),
+				  )
+			ShouldBeSameTypeAs rejected:
+				got value of type string; wanted a value of type int
+		`))
+	})
+	t.Run("none given", func(t *testing.T) {
+		_, passed := AnyOf()("a", "a")
+		if passed {
+			t.Errorf("should reject")
+		}
+	})
+}
@@ -1,30 +1,279 @@
 package wish
 
 import (
-	"fmt"
 	"strings"
+	"unicode"
 	"unicode/utf8"
 
 	"github.com/warpfork/go-wish/difflib"
+	"github.com/warpfork/go-wish/internal/norm"
 )
 
 func strdiff(a, b string) string {
-	result, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
-		A:       escapishSlice(strings.SplitAfter(a, "\n")),
-		B:       escapishSlice(strings.SplitAfter(b, "\n")),
-		Context: 3,
+	return strdiffContext(a, b, 3, nil)
+}
+
+// strdiff diffs two strings as configured: with strndiff if the NDiff option
+// was given, and with strdiffContext otherwise.
+func (cfg *config) strdiff(a, b string) string {
+	if cfg.ndiff {
+		return strndiff(a, b, cfg.diffAlgorithm)
+	}
+	return strdiffContext(a, b, cfg.contextLines, cfg.diffAlgorithm)
+}
+
+// strndiff produces a delta of two strings, line by line, in the style of
+// difflib.NDiff: every line of both strings is shown, and guide lines mark
+// the changes within lines which are similar.  An empty string is returned
+// if the strings are equal.
+//
+// Lines which differ only by invisible characters are shown escaped,
+// as in strdiffContext.
+//
+// If alg is nil, difflib's default algorithm is used.
+func strndiff(a, b string, alg difflib.Algorithm) string {
+	if a == b {
+		return ""
+	}
+	shownA, shownB, _, escaped := showLines(a, b, alg)
+	d := difflib.Differ{CharJunk: difflib.IsCharacterJunk, Algorithm: alg}
+	return strings.Join(d.Compare(shownA, shownB), "") + escapedNote(escaped)
+}
+
+// strdiffContext produces a unified diff of two strings, line by line.
+//
+// Lines are shown as raw text, except where a line differs from its
+// counterpart only by invisible characters (see invisibleDifference);
+// those lines are shown escaped (see EscapeToASCII), so the difference
+// can be seen, and a note explaining this is appended to the diff.
+//
+// If alg is nil, difflib's default algorithm is used.
+func strdiffContext(a, b string, context int, alg difflib.Algorithm) string {
+	shownA, shownB, opCodes, escaped := showLines(a, b, alg)
+	var sb strings.Builder
+	difflib.WriteUnifiedDiff(&sb, difflib.UnifiedDiff{
+		A:         shownA,
+		B:         shownB,
+		Context:   context,
+		Algorithm: fixedOpCodes(opCodes),
 	})
-	if err != nil {
-		panic(fmt.Errorf("diffing failed: %s", err))
+	return markIntraline(sb.String()) + escapedNote(escaped)
+}
+
+// showLines splits two strings into lines, diffs them with alg, and returns
+// the lines as they should be shown, each ending in a newline: raw, except
+// for changed lines which differ only by invisible characters, which are
+// escaped.  The opcodes describe the diff of the lines, and escaped says
+// whether any were.
+func showLines(a, b string, alg difflib.Algorithm) (shownA, shownB []string, opCodes []difflib.OpCode, escaped bool) {
+	linesA, linesB := strings.SplitAfter(a, "\n"), strings.SplitAfter(b, "\n")
+	if alg == nil {
+		alg = difflib.RatcliffObershelp
+	}
+	opCodes = alg.OpCodes(linesA, linesB)
+	escapeA, escapeB := make([]bool, len(linesA)), make([]bool, len(linesB))
+	for _, op := range opCodes {
+		if op.Tag == 'e' {
+			continue
+		}
+		for k := 0; k < op.I2-op.I1 || k < op.J2-op.J1; k++ {
+			i, j := op.I1+k, op.J1+k
+			switch {
+			case i < op.I2 && j < op.J2:
+				escapeA[i] = invisibleDifference(linesA[i], linesB[j])
+				escapeB[j] = escapeA[i]
+			case i < op.I2:
+				escapeA[i] = invisibleLine(linesA[i])
+			default:
+				escapeB[j] = invisibleLine(linesB[j])
+			}
+		}
 	}
-	return result
+	show := func(lines []string, escape []bool) []string {
+		shown := make([]string, len(lines))
+		for i, line := range lines {
+			if escape[i] {
+				shown[i] = EscapeToASCII(line) + "\n"
+				escaped = true
+			} else {
+				shown[i] = strings.TrimSuffix(line, "\n") + "\n"
+			}
+		}
+		return shown
+	}
+	return show(linesA, escapeA), show(linesB, escapeB), opCodes, escaped
+}
+
+// escapedNote explains escaped lines at the end of a diff, if there were any.
+func escapedNote(escaped bool) string {
+	if !escaped {
+		return ""
+	}
+	return "(lines which differ only by invisible characters are shown escaped)\n"
+}
+
+// fixedOpCodes is a difflib.Algorithm which answers with opcodes that were
+// already computed, so lines can be diffed as they are, but shown differently.
+type fixedOpCodes []difflib.OpCode
+
+func (codes fixedOpCodes) OpCodes(a, b []string) []difflib.OpCode {
+	return codes
+}
+
+// invisibleDifference returns true if the two lines differ, but only in ways
+// that would be hard or impossible to see when printed: whitespace, line
+// endings, control and zero-width characters, and unicode normalization form.
+//
+// The lines are compared decomposed (NFD), so a precomposed character and
+// the same character spelled with a combining mark are equal; any combining
+// mark which is still added, removed, or changed after that is visible.
+func invisibleDifference(a, b string) bool {
+	ra, rb, m := runeMatcher(norm.NFD.String(a), norm.NFD.String(b))
+	for _, op := range m.GetOpCodes() {
+		if op.Tag == 'e' {
+			continue
+		}
+		spanA, spanB := ra[op.I1:op.I2], rb[op.J1:op.J2]
+		if hasCombiningMark(spanA) || hasCombiningMark(spanB) {
+			return false
+		}
+		if !allInvisible(spanA) || !allInvisible(spanB) {
+			return false
+		}
+	}
+	return true
+}
+
+// invisibleLine returns true if the line has content, but none of it
+// would be visible when printed.  (An empty line is obvious enough.)
+func invisibleLine(line string) bool {
+	line = strings.TrimSuffix(line, "\n")
+	return line != "" && allInvisible(splitRunes(line))
+}
+
+func allInvisible(runes []string) bool {
+	for _, s := range runes {
+		r, _ := utf8.DecodeRuneInString(s)
+		if !unicode.IsSpace(r) && !unicode.IsControl(r) && !unicode.In(r, unicode.Cf, unicode.Mn) {
+			return false
+		}
+	}
+	return true
+}
+
+func hasCombiningMark(runes []string) bool {
+	for _, s := range runes {
+		r, _ := utf8.DecodeRuneInString(s)
+		if unicode.Is(unicode.Mn, r) {
+			return true
+		}
+	}
+	return false
+}
+
+// intralineCutoff is how similar (per SequenceMatcher.Ratio) a removed line
+// and an inserted line must be for markIntraline to mark their differences.
+// Less similar lines are just different lines, and marks would be noise.
+const intralineCutoff = 0.75
+
+// markIntraline looks for lines in a unified diff which were replaced by
+// similar lines, and adds a guide line (prefixed by "? ") under each of them,
+// with carets marking exactly which characters changed.
+//
+// Within each run of removed lines followed by inserted lines,
+// the lines are paired in order.
+func markIntraline(diff string) string {
+	lines := strings.SplitAfter(diff, "\n")
+	var sb strings.Builder
+	for i := 0; i < len(lines); {
+		if !strings.HasPrefix(lines[i], "- ") {
+			sb.WriteString(lines[i])
+			i++
+			continue
+		}
+		removed := i
+		for i < len(lines) && strings.HasPrefix(lines[i], "- ") {
+			i++
+		}
+		inserted := i
+		for i < len(lines) && strings.HasPrefix(lines[i], "+ ") {
+			i++
+		}
+		nRemoved, nInserted := inserted-removed, i-inserted
+		guides := make([]string, i-removed)
+		for k := 0; k < nRemoved && k < nInserted; k++ {
+			guides[k], guides[nRemoved+k] = intralineGuides(lines[removed+k][2:], lines[inserted+k][2:])
+		}
+		for k, line := range lines[removed:i] {
+			sb.WriteString(line)
+			if guides[k] != "" {
+				sb.WriteString(guides[k])
+			}
+		}
+	}
+	return sb.String()
+}
+
+// intralineGuides compares two lines rune by rune, and returns a guide line
+// for each (or empty strings, if the lines aren't similar enough).
+func intralineGuides(a, b string) (guideA, guideB string) {
+	ra, rb, m := runeMatcher(strings.TrimSuffix(a, "\n"), strings.TrimSuffix(b, "\n"))
+	if m.Ratio() < intralineCutoff {
+		return "", ""
+	}
+	marksA, marksB := make([]bool, len(ra)), make([]bool, len(rb))
+	for _, op := range m.GetOpCodes() {
+		if op.Tag == 'e' {
+			continue
+		}
+		for k := op.I1; k < op.I2; k++ {
+			marksA[k] = true
+		}
+		for k := op.J1; k < op.J2; k++ {
+			marksB[k] = true
+		}
+	}
+	return guideLine(ra, marksA), guideLine(rb, marksB)
+}
+
+// guideLine renders carets under the marked runes.  Tabs in the line are
+// copied into the guide, so the carets stay aligned with the text above.
+// If nothing is marked, the empty string is returned.
+func guideLine(runes []string, marks []bool) string {
+	var sb strings.Builder
+	sb.WriteString("? ")
+	last := -1
+	for k, r := range runes {
+		switch {
+		case marks[k]:
+			sb.WriteByte('^')
+			last = sb.Len()
+		case r == "\t":
+			sb.WriteByte('\t')
+		default:
+			sb.WriteByte(' ')
+		}
+	}
+	if last < 0 {
+		return ""
+	}
+	return sb.String()[:last] + "\n"
+}
+
+// runeMatcher returns a SequenceMatcher comparing two strings rune by rune.
+// Autojunk is disabled; in a long line, common characters would otherwise be
+// treated as junk, and never matched.
+func runeMatcher(a, b string) (ra, rb []string, m *difflib.SequenceMatcher) {
+	ra, rb = splitRunes(a), splitRunes(b)
+	return ra, rb, difflib.NewMatcherWithJunk(ra, rb, false, nil)
 }
 
-func escapishSlice(ss []string) []string {
-	for i, s := range ss {
-		ss[i] = EscapeToASCII(s) + "\n"
+func splitRunes(s string) []string {
+	runes := make([]string, 0, len(s))
+	for _, r := range s {
+		runes = append(runes, string(r))
 	}
-	return ss
+	return runes
 }
 
 const lowerhex = "0123456789abcdef"
@@ -36,9 +285,9 @@ const lowerhex = "0123456789abcdef"
 // Included in the escaped values are '\n'; excluded are spaces and quote marks
 // (this latter part being a notable distinction from `strconv.QuoteToASCII`).
 //
-// go-wish features for string comparison may use EscapeToASCII automatically
-// to help highlight whitespace and other hard-to-see differences if it detects
-// strings differing only by such values.
+// go-wish features for string comparison use EscapeToASCII automatically
+// to help highlight whitespace and other hard-to-see differences when they
+// detect lines differing only by such values.
 func EscapeToASCII(s string) string {
 	return string(appendEscaped(make([]byte, 0, 3*len(s)/2), s))
 }
@@ -0,0 +1,154 @@
+package wish
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestIntralineMarks(t *testing.T) {
+	t.Run("similar lines are marked", func(t *testing.T) {
+		shouldStringMatch(t, strdiff(
+			"a line where one word differs\nsame",
+			"a line where one ward differs\nsame",
+		), Dedent(`
+			@@ -1,2 +1,2 @@
+			- a line where one word differs
+			?                   ^
+			+ a line where one ward differs
+			?                   ^
+			  same
+		`))
+	})
+	t.Run("insertions and deletions are marked on their own side", func(t *testing.T) {
+		shouldStringMatch(t, strdiff(
+			"the quick brown fox jumps",
+			"the brown fox jumps over",
+		), Dedent(`
+			@@ -1 +1 @@
+			- the quick brown fox jumps
+			?    ^^^^^^
+			+ the brown fox jumps over
+			?                    ^^^^^
+		`))
+	})
+	t.Run("dissimilar lines are not marked", func(t *testing.T) {
+		shouldStringMatch(t, strdiff(
+			"foobar",
+			"bazfomp",
+		), Dedent(`
+			@@ -1 +1 @@
+			- foobar
+			+ bazfomp
+		`))
+	})
+	t.Run("lines are paired in order", func(t *testing.T) {
+		shouldStringMatch(t, strdiff(
+			"alpha one\nbravo two",
+			"alpha 1ne\nbravo tw0\ncharlie",
+		), Dedent(`
+			@@ -1,2 +1,3 @@
+			- alpha one
+			?       ^
+			- bravo two
+			?         ^
+			+ alpha 1ne
+			?       ^
+			+ bravo tw0
+			?         ^
+			+ charlie
+		`))
+	})
+	t.Run("long lines", func(t *testing.T) {
+		a := "0123456789abcdefghij0123456789abcdefghij0123456789abcdefghij0123456789abcdefghij0123456789abcdefghij0123456789abcdefghij0123456789abcdefghij0123456789abcdefghij0123456789abcdefghij0123456789abcdefghij"
+		b := a[:150] + "X" + a[151:]
+		guideA, guideB := intralineGuides(a, b)
+		shouldStringMatch(t, guideA, "? "+strings.Repeat(" ", 150)+"^\n")
+		shouldStringMatch(t, guideB, "? "+strings.Repeat(" ", 150)+"^\n")
+	})
+}
+
+func TestInvisibleDifferences(t *testing.T) {
+	t.Run("unicode text is shown raw", func(t *testing.T) {
+		shouldStringMatch(t, strdiff(
+			"größe: 1\nnaïve",
+			"größe: 2\nnaïve",
+		), Dedent(`
+			@@ -1,2 +1,2 @@
+			- größe: 1
+			?        ^
+			+ größe: 2
+			?        ^
+			  naïve
+		`))
+	})
+	t.Run("trailing whitespace is escaped", func(t *testing.T) {
+		shouldStringMatch(t, strdiff(
+			"a\tb\nc",
+			"a\tb \nc",
+		), Dedent(`
+			@@ -1,2 +1,2 @@
+			- a\tb\n
+			+ a\tb \n
+			?     ^
+			  c
+			(lines which differ only by invisible characters are shown escaped)
+		`))
+	})
+	t.Run("line endings are escaped", func(t *testing.T) {
+		shouldStringMatch(t, strdiff(
+			"a\nb\n",
+			"a\r\nb\r\n",
+		), Dedent(`
+			@@ -1,3 +1,3 @@
+			- a\n
+			- b\n
+			+ a\r\n
+			?   ^^
+			+ b\r\n
+			?   ^^
+			  
+			(lines which differ only by invisible characters are shown escaped)
+		`))
+	})
+	t.Run("zero-width characters are escaped", func(t *testing.T) {
+		shouldStringMatch(t, strdiff(
+			"zero\u200bwidth",
+			"zerowidth",
+		), Dedent(`
+			@@ -1 +1 @@
+			- zero\u200bwidth
+			?     ^^^^^^
+			+ zerowidth
+			(lines which differ only by invisible characters are shown escaped)
+		`))
+	})
+	t.Run("normalization forms are escaped", func(t *testing.T) {
+		shouldStringMatch(t, strdiff(
+			"caf\u00e9",
+			"cafe\u0301",
+		), Dedent(`
+			@@ -1 +1 @@
+			- caf\u00e9
+			+ cafe\u0301
+			(lines which differ only by invisible characters are shown escaped)
+		`))
+	})
+	t.Run("changed combining marks are shown raw", func(t *testing.T) {
+		shouldStringMatch(t, strdiff(
+			"caf\u00e9",
+			"cafa\u0301",
+		), "@@ -1 +1 @@\n- caf\u00e9\n+ cafa\u0301\n")
+	})
+	t.Run("whitespace-only lines are escaped", func(t *testing.T) {
+		shouldStringMatch(t, strdiff(
+			"a\nb",
+			"a\n \t\nb",
+		), Dedent(`
+			@@ -1,2 +1,3 @@
+			  a
+			+  \t\n
+			  b
+			(lines which differ only by invisible characters are shown escaped)
+		`))
+	})
+}
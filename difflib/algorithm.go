@@ -0,0 +1,360 @@
+package difflib
+
+// Algorithm is a way of comparing two sequences of strings.
+//
+// All algorithms describe their result in the same way, as a list of opcodes
+// (see SequenceMatcher.GetOpCodes), so any of them can be used to produce
+// unified or context diffs (see UnifiedDiff.Algorithm), or grouped into hunks
+// with GroupOpCodes.  They differ in which of the many possible descriptions
+// of a change they choose, and in how long it takes them to choose it.
+type Algorithm interface {
+	// OpCodes returns a list of opcodes describing how to turn a into b.
+	OpCodes(a, b []string) []OpCode
+}
+
+var (
+	// RatcliffObershelp is the algorithm used by SequenceMatcher (with
+	// automatic junk heuristics enabled), and the default.  It finds matches
+	// that tend to "look right" to people, but does not produce minimal
+	// diffs, and is quadratic in the worst case, which is often hit by
+	// inputs with many repeated lines.
+	RatcliffObershelp Algorithm = ratcliffObershelp{}
+
+	// Myers is the O(ND) algorithm described by Eugene Myers in "An O(ND)
+	// Difference Algorithm and Its Variations" (1986), which produces minimal
+	// diffs.  It runs in time proportional to the size of the inputs times the
+	// size of the difference, and in linear space.
+	Myers Algorithm = myers{}
+
+	// Patience is the patience diff algorithm, as described by Bram Cohen.
+	// It anchors the diff on lines which appear exactly once in each input,
+	// which tends to keep distinctive lines (like function signatures) matched
+	// up, and avoids matching on repetitive lines (like lone braces).
+	// Stretches with no unique lines are compared with Myers.
+	Patience Algorithm = patience{}
+
+	// Histogram is the histogram diff algorithm, as found in jgit and git.
+	// It's an extension of Patience which anchors on the least frequent
+	// lines, rather than only unique lines, so it finds anchors in more kinds
+	// of input.  Stretches where every line is very frequent are compared
+	// with Myers.
+	Histogram Algorithm = histogram{}
+)
+
+type ratcliffObershelp struct{}
+
+func (ratcliffObershelp) OpCodes(a, b []string) []OpCode {
+	return NewMatcher(a, b).GetOpCodes()
+}
+
+// interner assigns each distinct string a small integer, so the algorithms
+// below can compare elements cheaply.
+type interner map[string]int
+
+func (in interner) intern(seq []string) []int {
+	ids := make([]int, len(seq))
+	for i, s := range seq {
+		id, ok := in[s]
+		if !ok {
+			id = len(in)
+			in[s] = id
+		}
+		ids[i] = id
+	}
+	return ids
+}
+
+// matcher accumulates the matches found by an algorithm, in order,
+// and turns them into opcodes.
+type matcher struct {
+	a, b    []int
+	matches []Match
+}
+
+func newMatcher(a, b []string) *matcher {
+	in := interner{}
+	return &matcher{a: in.intern(a), b: in.intern(b)}
+}
+
+// match records that a[i:i+n] == b[j:j+n].  Matches must be recorded in
+// increasing order; adjacent matches are merged.
+func (m *matcher) match(i, j, n int) {
+	if n == 0 {
+		return
+	}
+	if k := len(m.matches) - 1; k >= 0 {
+		last := &m.matches[k]
+		if last.A+last.Size == i && last.B+last.Size == j {
+			last.Size += n
+			return
+		}
+	}
+	m.matches = append(m.matches, Match{i, j, n})
+}
+
+// trim matches and strips any common prefix and suffix of a[aLo:aHi] and
+// b[bLo:bHi], and returns the remaining ranges, and the length of the
+// suffix, which the caller must match after handling the remaining ranges.
+func (m *matcher) trim(aLo, aHi, bLo, bHi int) (int, int, int, int, int) {
+	n := 0
+	for aLo+n < aHi && bLo+n < bHi && m.a[aLo+n] == m.b[bLo+n] {
+		n++
+	}
+	m.match(aLo, bLo, n)
+	aLo, bLo = aLo+n, bLo+n
+	n = 0
+	for aLo < aHi-n && bLo < bHi-n && m.a[aHi-n-1] == m.b[bHi-n-1] {
+		n++
+	}
+	return aLo, aHi - n, bLo, bHi - n, n
+}
+
+func (m *matcher) opCodes() []OpCode {
+	return opCodesFromMatches(append(m.matches, Match{len(m.a), len(m.b), 0}))
+}
+
+type myers struct{}
+
+func (myers) OpCodes(a, b []string) []OpCode {
+	m := newMatcher(a, b)
+	m.myers(0, len(m.a), 0, len(m.b))
+	return m.opCodes()
+}
+
+// myers finds a shortest edit script for a[aLo:aHi] and b[bLo:bHi],
+// by finding the middle snake of an optimal path, and recursing on
+// either side of it.
+func (m *matcher) myers(aLo, aHi, bLo, bHi int) {
+	aLo, aHi, bLo, bHi, suffix := m.trim(aLo, aHi, bLo, bHi)
+	if aLo < aHi && bLo < bHi {
+		x, y, u, v := m.middleSnake(aLo, aHi, bLo, bHi)
+		m.myers(aLo, x, bLo, y)
+		m.match(x, y, u-x)
+		m.myers(u, aHi, v, bHi)
+	}
+	m.match(aHi, bHi, suffix)
+}
+
+// middleSnake returns the start (x, y) and end (u, v) of the middle snake
+// of a shortest path from (aLo, bLo) to (aHi, bHi), per section 4b of the
+// Myers paper.  The search runs forward from the start and backward from the
+// end at once, until the two meet.  Both ranges must be non-empty, and the
+// first and last elements of the ranges must differ.
+//
+// Diagonals are numbered k = x - y, relative to the start for the forward
+// search, and relative to the end, in reversed coordinates, for the backward
+// search.  vf[k] and vb[k] hold the furthest x reached on each diagonal.
+func (m *matcher) middleSnake(aLo, aHi, bLo, bHi int) (x, y, u, v int) {
+	n, mm := aHi-aLo, bHi-bLo
+	delta := n - mm
+	odd := delta%2 != 0
+	dMax := (n + mm + 1) / 2
+	off := dMax + 1
+	vf := make([]int, 2*dMax+3)
+	vb := make([]int, 2*dMax+3)
+	for d := 0; d <= dMax; d++ {
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && vf[off+k-1] < vf[off+k+1]) {
+				x = vf[off+k+1]
+			} else {
+				x = vf[off+k-1] + 1
+			}
+			y := x - k
+			x0, y0 := x, y
+			for x < n && y < mm && m.a[aLo+x] == m.b[bLo+y] {
+				x, y = x+1, y+1
+			}
+			vf[off+k] = x
+			// The backward search has done d-1 steps; does it overlap?
+			if odd && delta-k >= -(d-1) && delta-k <= d-1 && x+vb[off+delta-k] >= n {
+				return aLo + x0, bLo + y0, aLo + x, bLo + y
+			}
+		}
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && vb[off+k-1] < vb[off+k+1]) {
+				x = vb[off+k+1]
+			} else {
+				x = vb[off+k-1] + 1
+			}
+			y := x - k
+			x0, y0 := x, y
+			for x < n && y < mm && m.a[aHi-x-1] == m.b[bHi-y-1] {
+				x, y = x+1, y+1
+			}
+			vb[off+k] = x
+			// The forward search has done d steps; does it overlap?
+			if !odd && delta-k >= -d && delta-k <= d && x+vf[off+delta-k] >= n {
+				return aHi - x, bHi - y, aHi - x0, bHi - y0
+			}
+		}
+	}
+	panic("unreachable: no middle snake found")
+}
+
+type patience struct{}
+
+func (patience) OpCodes(a, b []string) []OpCode {
+	m := newMatcher(a, b)
+	m.patience(0, len(m.a), 0, len(m.b))
+	return m.opCodes()
+}
+
+// patience anchors on the longest increasing subsequence of the elements
+// which are unique in both a[aLo:aHi] and b[bLo:bHi], and recurses
+// between the anchors.
+func (m *matcher) patience(aLo, aHi, bLo, bHi int) {
+	aLo, aHi, bLo, bHi, suffix := m.trim(aLo, aHi, bLo, bHi)
+	if aLo < aHi && bLo < bHi {
+		anchors := m.uniqueAnchors(aLo, aHi, bLo, bHi)
+		if len(anchors) == 0 {
+			m.myers(aLo, aHi, bLo, bHi)
+		} else {
+			i, j := aLo, bLo
+			for _, anchor := range anchors {
+				m.patience(i, anchor.A, j, anchor.B)
+				m.match(anchor.A, anchor.B, 1)
+				i, j = anchor.A+1, anchor.B+1
+			}
+			m.patience(i, aHi, j, bHi)
+		}
+	}
+	m.match(aHi, bHi, suffix)
+}
+
+// uniqueAnchors finds the elements which occur exactly once in each range,
+// and returns the longest sequence of them which appears in the same order
+// in both, using patience sorting.
+func (m *matcher) uniqueAnchors(aLo, aHi, bLo, bHi int) []Match {
+	type occurrence struct{ countA, countB, posA, posB int }
+	seen := map[int]*occurrence{}
+	for i := aLo; i < aHi; i++ {
+		o := seen[m.a[i]]
+		if o == nil {
+			o = &occurrence{}
+			seen[m.a[i]] = o
+		}
+		o.countA++
+		o.posA = i
+	}
+	for j := bLo; j < bHi; j++ {
+		if o := seen[m.b[j]]; o != nil {
+			o.countB++
+			o.posB = j
+		}
+	}
+	// Walk a in order, considering the unique common elements.
+	// Each pile holds the index of its top card in candidates, and
+	// each candidate links to the top of the previous pile when it was placed.
+	var candidates []Match
+	var prev []int
+	var piles []int
+	for i := aLo; i < aHi; i++ {
+		o := seen[m.a[i]]
+		if o.countA != 1 || o.countB != 1 {
+			continue
+		}
+		lo, hi := 0, len(piles)
+		for lo < hi {
+			mid := (lo + hi) / 2
+			if candidates[piles[mid]].B < o.posB {
+				lo = mid + 1
+			} else {
+				hi = mid
+			}
+		}
+		link := -1
+		if lo > 0 {
+			link = piles[lo-1]
+		}
+		candidates = append(candidates, Match{i, o.posB, 1})
+		prev = append(prev, link)
+		if lo == len(piles) {
+			piles = append(piles, len(candidates)-1)
+		} else {
+			piles[lo] = len(candidates) - 1
+		}
+	}
+	if len(piles) == 0 {
+		return nil
+	}
+	anchors := make([]Match, len(piles))
+	for k, c := len(piles)-1, piles[len(piles)-1]; k >= 0; k, c = k-1, prev[c] {
+		anchors[k] = candidates[c]
+	}
+	return anchors
+}
+
+type histogram struct{}
+
+func (histogram) OpCodes(a, b []string) []OpCode {
+	m := newMatcher(a, b)
+	m.histogram(0, len(m.a), 0, len(m.b))
+	return m.opCodes()
+}
+
+// histogramMaxChain is the number of occurrences in a, above which an element
+// is considered too common to anchor on.  (jgit uses the same limit.)
+const histogramMaxChain = 64
+
+// histogram finds the longest common region containing the rarest elements
+// of a[aLo:aHi] and b[bLo:bHi], and recurses on either side of it.
+func (m *matcher) histogram(aLo, aHi, bLo, bHi int) {
+	aLo, aHi, bLo, bHi, suffix := m.trim(aLo, aHi, bLo, bHi)
+	if aLo < aHi && bLo < bHi {
+		region, ok := m.rarestRegion(aLo, aHi, bLo, bHi)
+		if !ok {
+			m.myers(aLo, aHi, bLo, bHi)
+		} else {
+			m.histogram(aLo, region.A, bLo, region.B)
+			m.match(region.A, region.B, region.Size)
+			m.histogram(region.A+region.Size, aHi, region.B+region.Size, bHi)
+		}
+	}
+	m.match(aHi, bHi, suffix)
+}
+
+// rarestRegion looks at every match between the ranges, and extends each into
+// the longest region of matching elements around it.  Of those, it returns
+// the region whose rarest element (by count of occurrences in a) is rarest,
+// preferring longer regions among equals.
+func (m *matcher) rarestRegion(aLo, aHi, bLo, bHi int) (best Match, ok bool) {
+	positions := map[int][]int{}
+	for i := aLo; i < aHi; i++ {
+		positions[m.a[i]] = append(positions[m.a[i]], i)
+	}
+	bestCount := histogramMaxChain + 1
+	for j := bLo; j < bHi; {
+		next := j + 1
+		occurrences := positions[m.b[j]]
+		if len(occurrences) > bestCount {
+			j = next
+			continue
+		}
+		for _, i := range occurrences {
+			as, bs := i, j
+			for as > aLo && bs > bLo && m.a[as-1] == m.b[bs-1] {
+				as, bs = as-1, bs-1
+			}
+			ae, be := i+1, j+1
+			for ae < aHi && be < bHi && m.a[ae] == m.b[be] {
+				ae, be = ae+1, be+1
+			}
+			count := len(occurrences)
+			for k := as; k < ae; k++ {
+				if c := len(positions[m.a[k]]); c < count {
+					count = c
+				}
+			}
+			if count < bestCount || (count == bestCount && ae-as > best.Size) {
+				best, bestCount, ok = Match{as, bs, ae - as}, count, true
+			}
+			if be > next {
+				next = be
+			}
+		}
+		j = next
+	}
+	return best, ok
+}
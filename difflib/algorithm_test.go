@@ -0,0 +1,214 @@
+package difflib
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+var algorithms = []struct {
+	name string
+	alg  Algorithm
+}{
+	{"RatcliffObershelp", RatcliffObershelp},
+	{"Myers", Myers},
+	{"Patience", Patience},
+	{"Histogram", Histogram},
+}
+
+// checkOpCodes verifies that the opcodes are contiguous, cover both
+// sequences, and that applying them to a yields b.  It returns the number of
+// elements which were not matched (a measure of the size of the diff).
+func checkOpCodes(t *testing.T, a, b []string, codes []OpCode) (edits int) {
+	t.Helper()
+	i, j := 0, 0
+	var result []string
+	for _, c := range codes {
+		if c.I1 != i || c.J1 != j {
+			t.Fatalf("opcode %v is not contiguous with (%d, %d)", c, i, j)
+		}
+		switch c.Tag {
+		case 'e':
+			if c.I2-c.I1 != c.J2-c.J1 {
+				t.Fatalf("equal opcode %v has ranges of different lengths", c)
+			}
+			for k := 0; k < c.I2-c.I1; k++ {
+				if a[c.I1+k] != b[c.J1+k] {
+					t.Fatalf("equal opcode %v covers different elements", c)
+				}
+			}
+			result = append(result, a[c.I1:c.I2]...)
+		case 'r', 'd', 'i':
+			result = append(result, b[c.J1:c.J2]...)
+			edits += (c.I2 - c.I1) + (c.J2 - c.J1)
+		default:
+			t.Fatalf("opcode %v has unknown tag", c)
+		}
+		i, j = c.I2, c.J2
+	}
+	if i != len(a) || j != len(b) {
+		t.Fatalf("opcodes end at (%d, %d), not (%d, %d)", i, j, len(a), len(b))
+	}
+	if strings.Join(result, "") != strings.Join(b, "") {
+		t.Fatalf("applying opcodes yields %q, not %q", result, b)
+	}
+	return edits
+}
+
+// lcsLength is the textbook quadratic dynamic program, for checking
+// that Myers finds minimal diffs.
+func lcsLength(a, b []string) int {
+	prev, curr := make([]int, len(b)+1), make([]int, len(b)+1)
+	for i := range a {
+		for j := range b {
+			if a[i] == b[j] {
+				curr[j+1] = prev[j] + 1
+			} else {
+				curr[j+1] = max(curr[j], prev[j+1])
+			}
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
+
+func randomSeq(r *rand.Rand, n int, alphabet string) []string {
+	seq := make([]string, n)
+	for i := range seq {
+		seq[i] = string(alphabet[r.Intn(len(alphabet))])
+	}
+	return seq
+}
+
+func TestAlgorithmsProduceValidOpCodes(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for _, tc := range algorithms {
+		t.Run(tc.name, func(t *testing.T) {
+			assertEqual(t, tc.alg.OpCodes(nil, nil), []OpCode{})
+			checkOpCodes(t, nil, splitChars("abc"), tc.alg.OpCodes(nil, splitChars("abc")))
+			checkOpCodes(t, splitChars("abc"), nil, tc.alg.OpCodes(splitChars("abc"), nil))
+			for n := 0; n < 200; n++ {
+				a := randomSeq(r, r.Intn(30), "abcd")
+				b := randomSeq(r, r.Intn(30), "abcd")
+				checkOpCodes(t, a, b, tc.alg.OpCodes(a, b))
+			}
+		})
+	}
+}
+
+func TestMyersIsMinimal(t *testing.T) {
+	r := rand.New(rand.NewSource(2))
+	for n := 0; n < 500; n++ {
+		a := randomSeq(r, r.Intn(40), "abc")
+		b := randomSeq(r, r.Intn(40), "abc")
+		edits := checkOpCodes(t, a, b, Myers.OpCodes(a, b))
+		if want := len(a) + len(b) - 2*lcsLength(a, b); edits != want {
+			t.Fatalf("diff of %q and %q has %d edits; minimal is %d", a, b, edits, want)
+		}
+	}
+}
+
+func TestMyersOpCodes(t *testing.T) {
+	// The example from the Myers paper.
+	a, b := splitChars("abcabba"), splitChars("cbabac")
+	codes := Myers.OpCodes(a, b)
+	assertEqual(t, checkOpCodes(t, a, b, codes), 5)
+}
+
+// Moving a function: patience and histogram keep the distinctive lines
+// together, rather than matching up the braces and blank lines.
+var movedFunctionA = SplitLines("func a() {\n\tone()\n}\n\nfunc b() {\n\ttwo()\n}")
+var movedFunctionB = SplitLines("func b() {\n\ttwo()\n}\n\nfunc a() {\n\tone()\n}")
+
+const movedFunctionDiff = "@@ -1,4 +0,0 @@\n" +
+	"- func a() {\n" +
+	"- \tone()\n" +
+	"- }\n" +
+	"- \n" +
+	"@@ -6,0 +3,4 @@\n" +
+	"+ }\n" +
+	"+ \n" +
+	"+ func a() {\n" +
+	"+ \tone()\n"
+
+func TestPatienceAnchorsOnUniqueLines(t *testing.T) {
+	diff, err := GetUnifiedDiffString(UnifiedDiff{A: movedFunctionA, B: movedFunctionB, Context: 0, Algorithm: Patience})
+	assertEqual(t, err, nil)
+	assertEqual(t, diff, movedFunctionDiff)
+}
+
+func TestHistogramAnchorsOnRareLines(t *testing.T) {
+	diff, err := GetUnifiedDiffString(UnifiedDiff{A: movedFunctionA, B: movedFunctionB, Context: 0, Algorithm: Histogram})
+	assertEqual(t, err, nil)
+	assertEqual(t, diff, movedFunctionDiff)
+
+	// With every line repeated, there's nothing unique for patience to anchor
+	// on, but histogram can still anchor on the rarer lines.
+	a := SplitLines("x\ny\nx\ny\nx\ny\nrare\nrare")
+	b := SplitLines("rare\nrare\nx\ny\nx\ny\nx\ny")
+	codes := Histogram.OpCodes(a, b)
+	checkOpCodes(t, a, b, codes)
+	assertEqual(t, codes, []OpCode{
+		{'d', 0, 6, 0, 0},
+		{'e', 6, 8, 0, 2},
+		{'i', 8, 8, 2, 8},
+	})
+}
+
+func TestUnifiedDiffAlgorithm(t *testing.T) {
+	a := SplitLines("a\nb\nc\na\nb\nb\na")
+	b := SplitLines("c\nb\na\nb\na\nc")
+	for _, tc := range algorithms {
+		diff := UnifiedDiff{A: a, B: b, Context: 3, Algorithm: tc.alg}
+		result, err := GetUnifiedDiffString(diff)
+		assertEqual(t, err, nil)
+		if !strings.HasPrefix(result, "@@ -1,7 +1,6 @@\n") {
+			t.Errorf("%s: unexpected diff:\n%s", tc.name, result)
+		}
+	}
+}
+
+// generateJSONish produces about size bytes of repetitive, JSON-like text,
+// and a copy with scattered edits: the sort of input with many repeated lines
+// which SequenceMatcher finds hard.
+func generateJSONish(size int) (a, b []string) {
+	r := rand.New(rand.NewSource(3))
+	var sb strings.Builder
+	for n := 0; sb.Len() < size; n++ {
+		fmt.Fprintf(&sb, "{\n\t\"id\": %d,\n\t\"tags\": [\n\t\t\"x\",\n\t\t\"y\"\n\t]\n},\n", n)
+	}
+	a = SplitLines(sb.String())
+	b = append([]string(nil), a...)
+	for n := 0; n < len(b)/100; n++ {
+		k := r.Intn(len(b))
+		switch r.Intn(3) {
+		case 0:
+			b[k] = "\t\"changed\": true,\n"
+		case 1:
+			b = append(b[:k], b[k+1:]...)
+		case 2:
+			b = append(b[:k], append([]string{"}\n"}, b[k:]...)...)
+		}
+	}
+	return a, b
+}
+
+func benchmarkAlgorithm(b *testing.B, alg Algorithm, size int) {
+	seqA, seqB := generateJSONish(size)
+	b.SetBytes(int64(size))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		alg.OpCodes(seqA, seqB)
+	}
+}
+
+// RatcliffObershelp is only benchmarked on the smaller input:
+// being quadratic, it takes many minutes on the larger one.
+func BenchmarkRatcliffObershelp100K(b *testing.B) { benchmarkAlgorithm(b, RatcliffObershelp, 100<<10) }
+func BenchmarkMyers100K(b *testing.B)             { benchmarkAlgorithm(b, Myers, 100<<10) }
+func BenchmarkPatience100K(b *testing.B)          { benchmarkAlgorithm(b, Patience, 100<<10) }
+func BenchmarkHistogram100K(b *testing.B)         { benchmarkAlgorithm(b, Histogram, 100<<10) }
+func BenchmarkMyers4M(b *testing.B)               { benchmarkAlgorithm(b, Myers, 4<<20) }
+func BenchmarkPatience4M(b *testing.B)            { benchmarkAlgorithm(b, Patience, 4<<20) }
+func BenchmarkHistogram4M(b *testing.B)           { benchmarkAlgorithm(b, Histogram, 4<<20) }
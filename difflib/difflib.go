@@ -10,9 +10,19 @@
 //
 // - context_diff
 //
-// Getting unified diffs was the main goal of the port. Keep in mind this code
-// is mostly suitable to output text differences in a human friendly way, there
-// are no guarantees generated diffs are consumable by patch(1).
+// - Differ, ndiff, and restore
+//
+// Besides SequenceMatcher's own algorithm, the Myers, patience, and histogram
+// diff algorithms are available, and any of them can be used to produce
+// unified and context diffs (see Algorithm).
+//
+// Unified diffs can also be parsed (see ParseUnifiedDiff), and applied
+// to text (see Apply), and two versions of a text can be combined with
+// a three-way merge (see Merge).
+//
+// Getting unified diffs was the main goal of the port.  By default, unified
+// diffs are written in a style meant for people to read, which patch(1) can't
+// consume; set UnifiedDiff.Format to FormatPOSIX for standard unified diffs.
 package difflib
 
 import (
@@ -374,8 +384,15 @@ func (m *SequenceMatcher) GetOpCodes() []OpCode {
 	if m.opCodes != nil {
 		return m.opCodes
 	}
+	m.opCodes = opCodesFromMatches(m.GetMatchingBlocks())
+	return m.opCodes
+}
+
+// opCodesFromMatches converts a list of matching blocks, in the form
+// returned by GetMatchingBlocks (including the terminating dummy block),
+// into the opcodes describing how to turn a into b.
+func opCodesFromMatches(matching []Match) []OpCode {
 	i, j := 0, 0
-	matching := m.GetMatchingBlocks()
 	opCodes := make([]OpCode, 0, len(matching))
 	for _, m := range matching {
 		//  invariant:  we've pumped out correct diffs to change
@@ -402,8 +419,7 @@ func (m *SequenceMatcher) GetOpCodes() []OpCode {
 			opCodes = append(opCodes, OpCode{'e', ai, i, bj, j})
 		}
 	}
-	m.opCodes = opCodes
-	return m.opCodes
+	return opCodes
 }
 
 // Isolate change clusters by eliminating ranges with no changes.
@@ -411,10 +427,20 @@ func (m *SequenceMatcher) GetOpCodes() []OpCode {
 // Return a generator of groups with up to n lines of context.
 // Each group is in the same format as returned by GetOpCodes().
 func (m *SequenceMatcher) GetGroupedOpCodes(n int) [][]OpCode {
+	return GroupOpCodes(m.GetOpCodes(), n)
+}
+
+// GroupOpCodes isolates change clusters in a list of opcodes (such as
+// returned by SequenceMatcher.GetOpCodes, or an Algorithm), eliminating
+// ranges with no changes.
+//
+// Return groups with up to n lines of context.  A negative n means three.
+// Each group is in the same format as the opcodes given.
+func GroupOpCodes(codes []OpCode, n int) [][]OpCode {
 	if n < 0 {
 		n = 3
 	}
-	codes := m.GetOpCodes()
+	codes = append([]OpCode(nil), codes...) // the ends are adjusted below
 	if len(codes) == 0 {
 		codes = []OpCode{OpCode{'e', 0, 1, 0, 1}}
 	}
@@ -533,8 +559,36 @@ type UnifiedDiff struct {
 	B        []string // Second sequence lines
 	ToFile   string   // Second file name
 	ToDate   string   // Second file time
-	Eol      string   // Headers end of line, defaults to LF
+	Eol      string   // End of line for headers and markers, defaults to LF
 	Context  int      // Number of context lines
+
+	Algorithm Algorithm // Diff algorithm, defaults to RatcliffObershelp
+	Format    Format    // Style of unified diff lines, defaults to FormatSpaced
+}
+
+// Format selects the style in which the lines of a unified diff are written.
+type Format int
+
+const (
+	// FormatSpaced prefixes each line with two characters ("  ", "- ", or
+	// "+ "), which is easier for people to read, but is not a standard
+	// unified diff.  It's the default.
+	FormatSpaced Format = iota
+
+	// FormatPOSIX writes a standard unified diff, as specified by POSIX for
+	// "diff -u", which tools like patch(1) and ParseUnifiedDiff can consume:
+	// lines are prefixed with a single character, and a line which does not
+	// end in a newline is followed by a "\ No newline at end of file" marker.
+	FormatPOSIX
+)
+
+// groupedOpCodes compares the two sequences with the configured algorithm.
+func (diff UnifiedDiff) groupedOpCodes() [][]OpCode {
+	alg := diff.Algorithm
+	if alg == nil {
+		alg = RatcliffObershelp
+	}
+	return GroupOpCodes(alg.OpCodes(diff.A, diff.B), diff.Context)
 }
 
 // Compare two sequences of lines; generate the delta as a unified diff.
@@ -556,6 +610,10 @@ type UnifiedDiff struct {
 // times.  Any or all of these may be specified using strings for
 // 'fromfile', 'tofile', 'fromfiledate', and 'tofiledate'.
 // The modification times are normally expressed in the ISO 8601 format.
+//
+// The lines are written in the style chosen by diff.Format.  Note that
+// by default, that's not a standard unified diff; use FormatPOSIX to
+// produce diffs that patch(1) can apply.
 func WriteUnifiedDiff(writer io.Writer, diff UnifiedDiff) error {
 	buf := bufio.NewWriter(writer)
 	defer buf.Flush()
@@ -572,9 +630,22 @@ func WriteUnifiedDiff(writer io.Writer, diff UnifiedDiff) error {
 		diff.Eol = "\n"
 	}
 
+	context, removed, inserted := "  ", "- ", "+ "
+	if diff.Format == FormatPOSIX {
+		context, removed, inserted = " ", "-", "+"
+	}
+	wl := func(prefix, line string) error {
+		if err := ws(prefix + line); err != nil {
+			return err
+		}
+		if diff.Format == FormatPOSIX && !strings.HasSuffix(line, "\n") {
+			return ws(diff.Eol + "\\ No newline at end of file" + diff.Eol)
+		}
+		return nil
+	}
+
 	started := false
-	m := NewMatcher(diff.A, diff.B)
-	for _, g := range m.GetGroupedOpCodes(diff.Context) {
+	for _, g := range diff.groupedOpCodes() {
 		if !started {
 			started = true
 			fromDate := ""
@@ -606,7 +677,7 @@ func WriteUnifiedDiff(writer io.Writer, diff UnifiedDiff) error {
 			i1, i2, j1, j2 := c.I1, c.I2, c.J1, c.J2
 			if c.Tag == 'e' {
 				for _, line := range diff.A[i1:i2] {
-					if err := ws("  " + line); err != nil {
+					if err := wl(context, line); err != nil {
 						return err
 					}
 				}
@@ -614,14 +685,14 @@ func WriteUnifiedDiff(writer io.Writer, diff UnifiedDiff) error {
 			}
 			if c.Tag == 'r' || c.Tag == 'd' {
 				for _, line := range diff.A[i1:i2] {
-					if err := ws("- " + line); err != nil {
+					if err := wl(removed, line); err != nil {
 						return err
 					}
 				}
 			}
 			if c.Tag == 'r' || c.Tag == 'i' {
 				for _, line := range diff.B[j1:j2] {
-					if err := ws("+ " + line); err != nil {
+					if err := wl(inserted, line); err != nil {
 						return err
 					}
 				}
@@ -700,8 +771,7 @@ func WriteContextDiff(writer io.Writer, diff ContextDiff) error {
 	}
 
 	started := false
-	m := NewMatcher(diff.A, diff.B)
-	for _, g := range m.GetGroupedOpCodes(diff.Context) {
+	for _, g := range UnifiedDiff(diff).groupedOpCodes() {
 		if !started {
 			started = true
 			fromDate := ""
@@ -120,6 +120,7 @@ four`
 		ToFile:   "Current",
 		ToDate:   "2010-04-02 10:20:52",
 		Context:  3,
+		Format:   FormatPOSIX,
 	}
 	result, _ := GetUnifiedDiffString(diff)
 	fmt.Println(strings.Replace(result, "\t", " ", -1))
@@ -359,9 +360,10 @@ func TestOutputFormatNoTrailingTabOnEmptyFiledate(t *testing.T) {
 
 func TestOmitFilenames(t *testing.T) {
 	diff := UnifiedDiff{
-		A:   SplitLines("o\nn\ne\n"),
-		B:   SplitLines("t\nw\no\n"),
-		Eol: "\n",
+		A:      SplitLines("o\nn\ne\n"),
+		B:      SplitLines("t\nw\no\n"),
+		Eol:    "\n",
+		Format: FormatPOSIX,
 	}
 	ud, err := GetUnifiedDiffString(diff)
 	assertEqual(t, err, nil)
@@ -424,3 +426,24 @@ func BenchmarkSplitLines100(b *testing.B) {
 func BenchmarkSplitLines10000(b *testing.B) {
 	benchmarkSplitLines(b, 10000)
 }
+
+func TestFormatSpaced(t *testing.T) {
+	text, err := GetUnifiedDiffString(UnifiedDiff{
+		A:       SplitLines("a\nb"),
+		B:       SplitLines("a\nc"),
+		Context: 1,
+	})
+	assertEqual(t, err, nil)
+	assertEqual(t, text, "@@ -1,2 +1,2 @@\n  a\n- b\n+ c\n")
+}
+
+func TestFormatPOSIXEmptyRanges(t *testing.T) {
+	text, err := GetUnifiedDiffString(UnifiedDiff{
+		A:       nil,
+		B:       []string{"new\n", "file"},
+		Context: 3,
+		Format:  FormatPOSIX,
+	})
+	assertEqual(t, err, nil)
+	assertEqual(t, text, "@@ -0,0 +1,2 @@\n+new\n+file\n\\ No newline at end of file\n")
+}
@@ -0,0 +1,137 @@
+package difflib
+
+// ThreeWayMerge parameters
+type ThreeWayMerge struct {
+	Base   []string // Lines of the common ancestor
+	Ours   []string // Lines of one version derived from Base
+	Theirs []string // Lines of another version derived from Base
+
+	// Markers, if set, writes each conflict into the merged lines, between
+	// conflict markers in the style of "diff3 -m" (or git's "diff3" conflict
+	// style).  Otherwise, conflicting lines are left out of the merged lines,
+	// and only reported as Conflicts.
+	Markers bool
+
+	OursLabel   string // Text following the "<<<<<<<" marker, if any
+	BaseLabel   string // Text following the "|||||||" marker, if any
+	TheirsLabel string // Text following the ">>>>>>>" marker, if any
+}
+
+// Conflict is a region which was changed differently in both versions
+// of a three-way merge.
+type Conflict struct {
+	// Line is the index in the merged lines where the conflict belongs.
+	// With markers, that's the index of its "<<<<<<<" line.
+	Line int
+
+	BaseStart   int // Index of the first line of the region in Base
+	OursStart   int // Index of the first line of the region in Ours
+	TheirsStart int // Index of the first line of the region in Theirs
+
+	Base   []string // Lines of the region in Base
+	Ours   []string // Lines of the region in Ours
+	Theirs []string // Lines of the region in Theirs
+}
+
+// Merge combines two versions of a sequence of lines, which were both derived
+// from a common base, and returns the merged lines, along with any conflicts.
+//
+// This is the diff3 algorithm.  Each version is compared with the base
+// (using a SequenceMatcher, without automatic junk heuristics), and the lines
+// of the base which are matched in both are taken as stable.  Between those,
+// a region which was changed in only one version takes that version's lines;
+// a region which was changed identically in both takes those lines; and a
+// region which was changed differently in both is a conflict.
+//
+// When markers are written, the lines should end in newlines
+// (see SplitLines), since each is followed directly by the next.
+func Merge(m ThreeWayMerge) (merged []string, conflicts []Conflict) {
+	base, ours, theirs := m.Base, m.Ours, m.Theirs
+	z, a, b := 0, 0, 0
+	for _, sync := range syncRegions(base, ours, theirs) {
+		baseChunk, oursChunk, theirsChunk := base[z:sync.base], ours[a:sync.ours], theirs[b:sync.theirs]
+		switch {
+		case sameLines(oursChunk, theirsChunk):
+			merged = append(merged, oursChunk...)
+		case sameLines(oursChunk, baseChunk):
+			merged = append(merged, theirsChunk...)
+		case sameLines(theirsChunk, baseChunk):
+			merged = append(merged, oursChunk...)
+		default:
+			conflicts = append(conflicts, Conflict{
+				Line:        len(merged),
+				BaseStart:   z,
+				OursStart:   a,
+				TheirsStart: b,
+				Base:        baseChunk,
+				Ours:        oursChunk,
+				Theirs:      theirsChunk,
+			})
+			if m.Markers {
+				merged = append(merged, marker("<<<<<<<", m.OursLabel))
+				merged = append(merged, oursChunk...)
+				merged = append(merged, marker("|||||||", m.BaseLabel))
+				merged = append(merged, baseChunk...)
+				merged = append(merged, "=======\n")
+				merged = append(merged, theirsChunk...)
+				merged = append(merged, marker(">>>>>>>", m.TheirsLabel))
+			}
+		}
+		merged = append(merged, base[sync.base:sync.base+sync.size]...)
+		z, a, b = sync.base+sync.size, sync.ours+sync.size, sync.theirs+sync.size
+	}
+	return merged, conflicts
+}
+
+func marker(mark, label string) string {
+	if label == "" {
+		return mark + "\n"
+	}
+	return mark + " " + label + "\n"
+}
+
+// syncRegion is a run of lines of the base which is matched, unchanged,
+// in both other versions.
+type syncRegion struct {
+	base, ours, theirs int // Index of the first line in each sequence
+	size               int
+}
+
+// syncRegions finds the lines of base which are matched in both ours and
+// theirs, by intersecting the matching blocks of each with base.
+// The last region is an empty one at the ends of all three sequences.
+func syncRegions(base, ours, theirs []string) []syncRegion {
+	oursBlocks := NewMatcherWithJunk(base, ours, false, nil).GetMatchingBlocks()
+	theirsBlocks := NewMatcherWithJunk(base, theirs, false, nil).GetMatchingBlocks()
+	var regions []syncRegion
+	for i, j := 0, 0; i < len(oursBlocks) && j < len(theirsBlocks); {
+		o, t := oursBlocks[i], theirsBlocks[j]
+		lo, hi := max(o.A, t.A), min(o.A+o.Size, t.A+t.Size)
+		if lo < hi {
+			regions = append(regions, syncRegion{
+				base:   lo,
+				ours:   o.B + (lo - o.A),
+				theirs: t.B + (lo - t.A),
+				size:   hi - lo,
+			})
+		}
+		if o.A+o.Size < t.A+t.Size {
+			i++
+		} else {
+			j++
+		}
+	}
+	return append(regions, syncRegion{len(base), len(ours), len(theirs), 0})
+}
+
+func sameLines(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
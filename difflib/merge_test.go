@@ -0,0 +1,85 @@
+package difflib
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMerge(t *testing.T) {
+	base := SplitLines("one\ntwo\nthree\nfour\nfive")
+	for _, tc := range []struct {
+		name         string
+		ours, theirs string
+		merged       string
+	}{
+		{"unchanged", "one\ntwo\nthree\nfour\nfive", "one\ntwo\nthree\nfour\nfive", "one\ntwo\nthree\nfour\nfive\n"},
+		{"changed in ours", "one\nTWO\nthree\nfour\nfive", "one\ntwo\nthree\nfour\nfive", "one\nTWO\nthree\nfour\nfive\n"},
+		{"changed in theirs", "one\ntwo\nthree\nfour\nfive", "one\ntwo\nthree\nFOUR\nfive", "one\ntwo\nthree\nFOUR\nfive\n"},
+		{"changed in both", "one\nTWO\nthree\nfour\nfive", "one\ntwo\nthree\nFOUR\nfive", "one\nTWO\nthree\nFOUR\nfive\n"},
+		{"changed identically", "one\nTWO\nthree\nfour", "one\nTWO\nthree\nfour", "one\nTWO\nthree\nfour\n"},
+		{"inserted and deleted", "zero\none\ntwo\nthree\nfour\nfive", "one\ntwo\nfour\nfive", "zero\none\ntwo\nfour\nfive\n"},
+	} {
+		merged, conflicts := Merge(ThreeWayMerge{Base: base, Ours: SplitLines(tc.ours), Theirs: SplitLines(tc.theirs)})
+		if len(conflicts) != 0 {
+			t.Errorf("%s: unexpected conflicts: %v", tc.name, conflicts)
+		}
+		if got := strings.Join(merged, ""); got != tc.merged {
+			t.Errorf("%s: merged to %q, wanted %q", tc.name, got, tc.merged)
+		}
+	}
+}
+
+func TestMergeConflicts(t *testing.T) {
+	m := ThreeWayMerge{
+		Base:   SplitLines("a\nb\nc\nd\ne"),
+		Ours:   SplitLines("a\nB\nc\nd\ne\nf"),
+		Theirs: SplitLines("a\nbee\nc\nd\ne"),
+	}
+	merged, conflicts := Merge(m)
+	assertEqual(t, strings.Join(merged, ""), "a\nc\nd\ne\nf\n")
+	assertEqual(t, conflicts, []Conflict{{
+		Line:      1,
+		BaseStart: 1, OursStart: 1, TheirsStart: 1,
+		Base:   []string{"b\n"},
+		Ours:   []string{"B\n"},
+		Theirs: []string{"bee\n"},
+	}})
+
+	m.Markers = true
+	m.OursLabel, m.TheirsLabel = "ours.txt", "theirs.txt"
+	merged, conflicts = Merge(m)
+	assertEqual(t, strings.Join(merged, ""), strings.Join([]string{
+		"a\n",
+		"<<<<<<< ours.txt\n",
+		"B\n",
+		"|||||||\n",
+		"b\n",
+		"=======\n",
+		"bee\n",
+		">>>>>>> theirs.txt\n",
+		"c\n",
+		"d\n",
+		"e\n",
+		"f\n",
+	}, ""))
+	assertEqual(t, len(conflicts), 1)
+	assertEqual(t, merged[conflicts[0].Line], "<<<<<<< ours.txt\n")
+}
+
+func TestMergeConflictingInsertions(t *testing.T) {
+	// Both versions add different lines at the same place in the base.
+	merged, conflicts := Merge(ThreeWayMerge{
+		Base:    SplitLines("x\ny"),
+		Ours:    SplitLines("x\nours\ny"),
+		Theirs:  SplitLines("x\ntheirs\ny"),
+		Markers: true,
+	})
+	assertEqual(t, strings.Join(merged, ""), "x\n<<<<<<<\nours\n|||||||\n=======\ntheirs\n>>>>>>>\ny\n")
+	assertEqual(t, conflicts, []Conflict{{
+		Line:      1,
+		BaseStart: 1, OursStart: 1, TheirsStart: 1,
+		Base:   []string{},
+		Ours:   []string{"ours\n"},
+		Theirs: []string{"theirs\n"},
+	}})
+}
@@ -0,0 +1,258 @@
+package difflib
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// Differ compares sequences of lines of text, and produces human-readable
+// differences, in the style of Python's difflib.Differ.
+//
+// Each line of a Differ delta begins with a two-letter code:
+//
+//	"- "    line unique to sequence 1
+//	"+ "    line unique to sequence 2
+//	"  "    line common to both sequences
+//	"? "    line not present in either input sequence
+//
+// Lines beginning with "? " attempt to guide the eye to intraline
+// differences, and are not present in either input sequence.
+// They mark characters which were replaced with "^", deleted with "-",
+// and inserted with "+".
+//
+// Unlike a unified diff, a Differ delta contains every line of both inputs,
+// so either can be recovered from it; see Restore.
+//
+// The lines compared should end in newlines (see SplitLines), since each
+// is written out followed directly by the next.
+type Differ struct {
+	// LineJunk, if set, reports lines which should be ignored when
+	// looking for matches (see SequenceMatcher.IsJunk).  See IsLineJunk.
+	LineJunk func(string) bool
+
+	// CharJunk, if set, reports characters which should be ignored when
+	// looking for intraline matches.  See IsCharacterJunk.
+	CharJunk func(string) bool
+
+	// Algorithm, if set, is used to find matching lines instead of
+	// a SequenceMatcher, in which case LineJunk is not used.
+	// Similar lines are always paired up using a SequenceMatcher.
+	Algorithm Algorithm
+}
+
+// Compare compares two sequences of lines, and returns the delta.
+func (d *Differ) Compare(a, b []string) []string {
+	var codes []OpCode
+	if d.Algorithm != nil {
+		codes = d.Algorithm.OpCodes(a, b)
+	} else {
+		codes = NewMatcherWithJunk(a, b, true, d.LineJunk).GetOpCodes()
+	}
+	var delta []string
+	for _, c := range codes {
+		switch c.Tag {
+		case 'r':
+			delta = d.fancyReplace(delta, a, c.I1, c.I2, b, c.J1, c.J2)
+		case 'd':
+			delta = dump(delta, "-", a, c.I1, c.I2)
+		case 'i':
+			delta = dump(delta, "+", b, c.J1, c.J2)
+		case 'e':
+			delta = dump(delta, " ", a, c.I1, c.I2)
+		}
+	}
+	return delta
+}
+
+// dump appends each of x[lo:hi] to the delta, prefixed by the tag.
+func dump(delta []string, tag string, x []string, lo, hi int) []string {
+	for _, line := range x[lo:hi] {
+		delta = append(delta, tag+" "+line)
+	}
+	return delta
+}
+
+func plainReplace(delta []string, a []string, alo, ahi int, b []string, blo, bhi int) []string {
+	// Dump the shorter block first: it reduces the burden on short-term
+	// memory if the blocks are of very different sizes.
+	if bhi-blo < ahi-alo {
+		delta = dump(delta, "+", b, blo, bhi)
+		return dump(delta, "-", a, alo, ahi)
+	}
+	delta = dump(delta, "-", a, alo, ahi)
+	return dump(delta, "+", b, blo, bhi)
+}
+
+// fancyReplace handles a block of lines in a which was replaced by a block of
+// lines in b.  It finds the most similar pair of lines, synchs up on that,
+// and marks the intraline differences between them, then recurses on the
+// blocks on either side.  If no pair of lines is similar enough, the blocks
+// are dumped as plain deletions and insertions.
+func (d *Differ) fancyReplace(delta []string, a []string, alo, ahi int, b []string, blo, bhi int) []string {
+	// Don't synch up unless the lines have a similarity score of at least
+	// cutoff; bestRatio tracks the best score seen so far.
+	bestRatio, cutoff := 0.74, 0.75
+	bestI, bestJ := 0, 0
+	eqi, eqj := -1, -1 // first indices of equal lines, if any
+	cruncher := NewMatcherWithJunk(nil, nil, true, d.CharJunk)
+	for j := blo; j < bhi; j++ {
+		cruncher.SetSeq2(splitRunes(b[j]))
+		for i := alo; i < ahi; i++ {
+			if a[i] == b[j] {
+				if eqi < 0 {
+					eqi, eqj = i, j
+				}
+				continue
+			}
+			cruncher.SetSeq1(splitRunes(a[i]))
+			// Computing similarity is expensive, so use the cheap upper bounds
+			// first, to skip what can't possibly beat the best so far.
+			if cruncher.RealQuickRatio() > bestRatio &&
+				cruncher.QuickRatio() > bestRatio &&
+				cruncher.Ratio() > bestRatio {
+				bestRatio, bestI, bestJ = cruncher.Ratio(), i, j
+			}
+		}
+	}
+	if bestRatio < cutoff {
+		// No non-identical "pretty close" pair.
+		if eqi < 0 {
+			// No identical pair either; treat it as a straight replace.
+			return plainReplace(delta, a, alo, ahi, b, blo, bhi)
+		}
+		// No close pair, but an identical pair; synch up on that.
+		bestI, bestJ = eqi, eqj
+	} else {
+		// There's a close pair, so forget the identical pair (if any).
+		eqi = -1
+	}
+
+	// Pump out diffs from before the synch point.
+	delta = d.fancyHelper(delta, a, alo, bestI, b, blo, bestJ)
+
+	// Do intraline marking on the synch pair.
+	aelt, belt := a[bestI], b[bestJ]
+	if eqi < 0 {
+		var atags, btags strings.Builder
+		cruncher.SetSeqs(splitRunes(aelt), splitRunes(belt))
+		for _, c := range cruncher.GetOpCodes() {
+			la, lb := c.I2-c.I1, c.J2-c.J1
+			switch c.Tag {
+			case 'r':
+				atags.WriteString(strings.Repeat("^", la))
+				btags.WriteString(strings.Repeat("^", lb))
+			case 'd':
+				atags.WriteString(strings.Repeat("-", la))
+			case 'i':
+				btags.WriteString(strings.Repeat("+", lb))
+			case 'e':
+				atags.WriteString(strings.Repeat(" ", la))
+				btags.WriteString(strings.Repeat(" ", lb))
+			}
+		}
+		delta = qformat(delta, aelt, belt, atags.String(), btags.String())
+	} else {
+		// The synch pair is identical.
+		delta = append(delta, "  "+aelt)
+	}
+
+	// Pump out diffs from after the synch point.
+	return d.fancyHelper(delta, a, bestI+1, ahi, b, bestJ+1, bhi)
+}
+
+func (d *Differ) fancyHelper(delta []string, a []string, alo, ahi int, b []string, blo, bhi int) []string {
+	switch {
+	case alo < ahi && blo < bhi:
+		return d.fancyReplace(delta, a, alo, ahi, b, blo, bhi)
+	case alo < ahi:
+		return dump(delta, "-", a, alo, ahi)
+	case blo < bhi:
+		return dump(delta, "+", b, blo, bhi)
+	}
+	return delta
+}
+
+// qformat appends a pair of similar lines, each followed by its guide line
+// (unless the guide would be empty).  Tabs in the lines are kept in the
+// guides, so the marks stay aligned.
+func qformat(delta []string, aline, bline, atags, btags string) []string {
+	atags = strings.TrimRightFunc(keepOriginalWhitespace(aline, atags), unicode.IsSpace)
+	btags = strings.TrimRightFunc(keepOriginalWhitespace(bline, btags), unicode.IsSpace)
+	delta = append(delta, "- "+aline)
+	if atags != "" {
+		delta = append(delta, "? "+atags+"\n")
+	}
+	delta = append(delta, "+ "+bline)
+	if btags != "" {
+		delta = append(delta, "? "+btags+"\n")
+	}
+	return delta
+}
+
+// keepOriginalWhitespace replaces each unmarked position in the tags with
+// the character from the line, if that character is whitespace.
+func keepOriginalWhitespace(line, tags string) string {
+	chars, marks := splitRunes(line), splitRunes(tags)
+	var sb strings.Builder
+	for k, mark := range marks {
+		if mark == " " && k < len(chars) && strings.TrimSpace(chars[k]) == "" {
+			sb.WriteString(chars[k])
+		} else {
+			sb.WriteString(mark)
+		}
+	}
+	return sb.String()
+}
+
+// splitRunes splits a line into its runes.
+func splitRunes(s string) []string {
+	chars := make([]string, 0, len(s))
+	for _, r := range s {
+		chars = append(chars, string(r))
+	}
+	return chars
+}
+
+var lineJunkPattern = regexp.MustCompile(`^\s*(?:#\s*)?$`)
+
+// IsLineJunk reports whether a line is ignorable: blank, or containing
+// only a single "#" and whitespace.  It can be used as a Differ.LineJunk.
+func IsLineJunk(line string) bool {
+	return lineJunkPattern.MatchString(line)
+}
+
+// IsCharacterJunk reports whether a character is ignorable: a space or a tab.
+// It can be used as a Differ.CharJunk, and is what NDiff uses.
+func IsCharacterJunk(ch string) bool {
+	return ch == " " || ch == "\t"
+}
+
+// NDiff compares two sequences of lines with a Differ, using IsCharacterJunk
+// for intraline comparisons, and returns the delta.
+func NDiff(a, b []string) []string {
+	d := Differ{CharJunk: IsCharacterJunk}
+	return d.Compare(a, b)
+}
+
+// Restore recovers one of the two sequences that produced a Differ delta:
+// sequence 1 if which is 1, or sequence 2 if which is 2.
+// Any other value of which panics.
+func Restore(delta []string, which int) []string {
+	var tag string
+	switch which {
+	case 1:
+		tag = "- "
+	case 2:
+		tag = "+ "
+	default:
+		panic("difflib.Restore: which must be 1 or 2")
+	}
+	var lines []string
+	for _, line := range delta {
+		if strings.HasPrefix(line, "  ") || strings.HasPrefix(line, tag) {
+			lines = append(lines, line[2:])
+		}
+	}
+	return lines
+}
@@ -0,0 +1,88 @@
+package difflib
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNDiff(t *testing.T) {
+	// The example from Python's documentation for ndiff.
+	a := SplitLines("one\ntwo\nthree")
+	b := SplitLines("ore\ntree\nemu")
+	delta := NDiff(a, b)
+	assertEqual(t, strings.Join(delta, ""), strings.Join([]string{
+		"- one\n",
+		"?  ^\n",
+		"+ ore\n",
+		"?  ^\n",
+		"- two\n",
+		"- three\n",
+		"?  -\n",
+		"+ tree\n",
+		"+ emu\n",
+	}, ""))
+	assertEqual(t, Restore(delta, 1), a)
+	assertEqual(t, Restore(delta, 2), b)
+}
+
+func TestDiffer(t *testing.T) {
+	// The example from Python's documentation for Differ.
+	a := SplitLines(strings.Join([]string{
+		"  1. Beautiful is better than ugly.",
+		"  2. Explicit is better than implicit.",
+		"  3. Simple is better than complex.",
+		"  4. Complex is better than complicated.",
+	}, "\n"))
+	b := SplitLines(strings.Join([]string{
+		"  1. Beautiful is better than ugly.",
+		"  3.   Simple is better than complex.",
+		"  4. Complicated is better than complex.",
+		"  5. Flat is better than nested.",
+	}, "\n"))
+	d := Differ{}
+	delta := d.Compare(a, b)
+	assertEqual(t, strings.Join(delta, ""), strings.Join([]string{
+		"    1. Beautiful is better than ugly.\n",
+		"-   2. Explicit is better than implicit.\n",
+		"-   3. Simple is better than complex.\n",
+		"+   3.   Simple is better than complex.\n",
+		"?     ++\n",
+		"-   4. Complex is better than complicated.\n",
+		"?            ^                     ---- ^\n",
+		"+   4. Complicated is better than complex.\n",
+		"?           ++++ ^                      ^\n",
+		"+   5. Flat is better than nested.\n",
+	}, ""))
+	assertEqual(t, Restore(delta, 1), a)
+	assertEqual(t, Restore(delta, 2), b)
+}
+
+func TestDifferKeepsTabsInGuides(t *testing.T) {
+	delta := NDiff([]string{"\tabcDefghiJkl\n"}, []string{"\tabcdefGhijkl\n"})
+	assertEqual(t, delta, []string{
+		"- \tabcDefghiJkl\n",
+		"? \t   ^  ^  ^\n",
+		"+ \tabcdefGhijkl\n",
+		"? \t   ^  ^  ^\n",
+	})
+}
+
+func TestDifferAlgorithm(t *testing.T) {
+	a := SplitLines("b\nx\nb\nx\nc")
+	b := SplitLines("c\nx\nb\nx\nb")
+	d := Differ{Algorithm: Myers}
+	delta := d.Compare(a, b)
+	assertEqual(t, Restore(delta, 1), a)
+	assertEqual(t, Restore(delta, 2), b)
+}
+
+func TestIsJunk(t *testing.T) {
+	assertEqual(t, IsLineJunk("\n"), true)
+	assertEqual(t, IsLineJunk("  #   \n"), true)
+	assertEqual(t, IsLineJunk("hello\n"), false)
+	assertEqual(t, IsLineJunk("#hello\n"), false)
+	assertEqual(t, IsCharacterJunk(" "), true)
+	assertEqual(t, IsCharacterJunk("\t"), true)
+	assertEqual(t, IsCharacterJunk("\n"), false)
+	assertEqual(t, IsCharacterJunk("x"), false)
+}
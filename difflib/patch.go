@@ -0,0 +1,281 @@
+package difflib
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// FilePatch is the part of a unified diff which applies to one file:
+// the file headers (if any), and the hunks of changes.
+type FilePatch struct {
+	FromFile string // First file name, from the "---" header
+	FromDate string // First file time, if any
+	ToFile   string // Second file name, from the "+++" header
+	ToDate   string // Second file time, if any
+	Hunks    []Hunk
+}
+
+// Hunk is one "@@" section of a unified diff.
+//
+// Line numbers are one-based, as in the diff text.  When a range is empty,
+// its line number is that of the line just before it.
+type Hunk struct {
+	FromLine  int    // First line of the range in the first file
+	FromCount int    // Number of lines of the range in the first file
+	ToLine    int    // First line of the range in the second file
+	ToCount   int    // Number of lines of the range in the second file
+	Section   string // Any text following the closing "@@" (often a function name)
+	Lines     []HunkLine
+}
+
+// HunkLine is one line of a hunk.
+type HunkLine struct {
+	// Kind is ' ' for a line of context, '-' for a line only in the first
+	// file, and '+' for a line only in the second file.
+	Kind byte
+
+	// Text is the line, including its line ending -- unless it was followed
+	// by a "\ No newline at end of file" marker, in which case it has none.
+	Text string
+}
+
+// from returns the lines of the hunk that are in the first file,
+// and to returns those that are in the second.
+func (h Hunk) from() []string { return h.side('-') }
+func (h Hunk) to() []string   { return h.side('+') }
+
+func (h Hunk) side(kind byte) []string {
+	var lines []string
+	for _, l := range h.Lines {
+		if l.Kind == ' ' || l.Kind == kind {
+			lines = append(lines, l.Text)
+		}
+	}
+	return lines
+}
+
+var hunkHeaderPattern = regexp.MustCompile(`^@@ -(\d+)(?:,(\d+))? \+(\d+)(?:,(\d+))? @@ ?(.*?)\r?\n?$`)
+
+// ParseUnifiedDiff parses text in the unified diff format, as produced by
+// "diff -u" or "git diff", which may contain changes to many files.
+//
+// Each file's changes begin with "---" and "+++" headers.  Hunks which come
+// before any headers (as in a diff of two sequences, written without file
+// names) are returned in a FilePatch with no names.  Any other lines outside
+// of hunks, such as the "diff --git" and "index" lines of git, are ignored.
+//
+// WriteUnifiedDiff produces this format when UnifiedDiff.Format is
+// FormatPOSIX.
+func ParseUnifiedDiff(text string) ([]FilePatch, error) {
+	lines := strings.SplitAfter(text, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	var patches []FilePatch
+	var current *FilePatch
+	for n := 0; n < len(lines); {
+		line := lines[n]
+		switch {
+		case strings.HasPrefix(line, "--- ") && n+1 < len(lines) && strings.HasPrefix(lines[n+1], "+++ "):
+			patches = append(patches, FilePatch{})
+			current = &patches[len(patches)-1]
+			current.FromFile, current.FromDate = parseFileHeader(line[4:])
+			current.ToFile, current.ToDate = parseFileHeader(lines[n+1][4:])
+			n += 2
+		case strings.HasPrefix(line, "@@ "):
+			if current == nil {
+				patches = append(patches, FilePatch{})
+				current = &patches[len(patches)-1]
+			}
+			hunk, next, err := parseHunk(lines, n)
+			if err != nil {
+				return nil, err
+			}
+			current.Hunks = append(current.Hunks, hunk)
+			n = next
+		default:
+			n++
+		}
+	}
+	return patches, nil
+}
+
+// parseFileHeader splits the rest of a "---" or "+++" header line
+// into the file name and time.
+func parseFileHeader(s string) (name, date string) {
+	s = strings.TrimRight(s, "\r\n")
+	if i := strings.IndexByte(s, '\t'); i >= 0 {
+		return s[:i], s[i+1:]
+	}
+	return s, ""
+}
+
+// parseHunk parses the hunk whose header is lines[n], and returns it,
+// along with the index of the first line after it.
+func parseHunk(lines []string, n int) (Hunk, int, error) {
+	m := hunkHeaderPattern.FindStringSubmatch(lines[n])
+	if m == nil {
+		return Hunk{}, 0, fmt.Errorf("line %d: malformed hunk header %q", n+1, strings.TrimRight(lines[n], "\r\n"))
+	}
+	hunk := Hunk{
+		FromLine:  atoi(m[1]),
+		FromCount: atoiDefault(m[2], 1),
+		ToLine:    atoi(m[3]),
+		ToCount:   atoiDefault(m[4], 1),
+		Section:   m[5],
+	}
+	from, to := hunk.FromCount, hunk.ToCount
+	for n++; from > 0 || to > 0; n++ {
+		if n >= len(lines) {
+			return Hunk{}, 0, fmt.Errorf("line %d: hunk ends early: %d lines of the first file and %d of the second are missing", n+1, from, to)
+		}
+		line := lines[n]
+		kind := byte(' ')
+		text := ""
+		switch {
+		case line == "\n" || line == "\r\n":
+			// A line of empty context, whose leading space was lost
+			// (as some editors and email clients do).
+			text = line
+		case line[0] == ' ' || line[0] == '-' || line[0] == '+':
+			kind, text = line[0], line[1:]
+		case line[0] == '\\':
+			hunk.noNewline()
+			continue
+		default:
+			return Hunk{}, 0, fmt.Errorf("line %d: unexpected line in hunk: %q", n+1, strings.TrimRight(line, "\r\n"))
+		}
+		switch kind {
+		case ' ':
+			from, to = from-1, to-1
+		case '-':
+			from--
+		case '+':
+			to--
+		}
+		if from < 0 || to < 0 {
+			return Hunk{}, 0, fmt.Errorf("line %d: hunk has more lines than its header says", n+1)
+		}
+		hunk.Lines = append(hunk.Lines, HunkLine{kind, text})
+	}
+	// The last line may still be followed by a marker.
+	if n < len(lines) && strings.HasPrefix(lines[n], "\\") {
+		hunk.noNewline()
+		n++
+	}
+	return hunk, n, nil
+}
+
+// noNewline handles a "\ No newline at end of file" marker, by removing the
+// line ending from the line before it.
+func (h *Hunk) noNewline() {
+	if len(h.Lines) == 0 {
+		return
+	}
+	last := &h.Lines[len(h.Lines)-1]
+	last.Text = strings.TrimSuffix(strings.TrimSuffix(last.Text, "\n"), "\r")
+}
+
+func atoi(s string) int {
+	n, _ := strconv.Atoi(s) // the pattern only matches digits
+	return n
+}
+
+func atoiDefault(s string, def int) int {
+	if s == "" {
+		return def
+	}
+	return atoi(s)
+}
+
+// Apply applies hunks, in order, to the lines of a file, and returns the
+// patched lines.  The lines should keep their line endings, exactly as in the
+// file: split it with strings.SplitAfter(s, "\n"), and drop the empty string
+// which that leaves last if the file ends in a newline.  (SplitLines is not
+// suitable, since it adds a newline to the last line, whether it had one.)
+//
+// Like patch(1), Apply tolerates some drift between the patch and the file.
+// If a hunk's lines aren't found at the line numbers its header states,
+// the nearest place where they are found is used instead, and later hunks are
+// expected to be offset in the same way.  If a hunk's lines aren't found
+// anywhere, up to fuzz lines of context at its beginning and end are ignored,
+// one more at a time, and the search is repeated.  A fuzz of zero requires
+// all context to match.  If a hunk still doesn't match, an error is returned.
+func Apply(lines []string, hunks []Hunk, fuzz int) ([]string, error) {
+	var result []string
+	src, offset := 0, 0
+	for n, hunk := range hunks {
+		from, to := hunk.from(), hunk.to()
+		leading, trailing := contextLength(hunk.Lines), contextLength(reversed(hunk.Lines))
+		expected := hunk.FromLine - 1
+		if hunk.FromCount == 0 {
+			expected = hunk.FromLine // an empty range follows the line it names
+		}
+		expected += offset
+		pos, applied := -1, false
+		for f := 0; f <= fuzz && !applied; f++ {
+			skipHead := min(f, leading)
+			skipTail := min(min(f, trailing), len(from)-skipHead)
+			if f > 0 && skipHead == min(f-1, leading) && skipTail == min(f-1, trailing) {
+				break // there's no more context to ignore
+			}
+			old := from[skipHead : len(from)-skipTail]
+			pos = findLines(lines, old, src, expected+skipHead)
+			if pos >= 0 {
+				result = append(result, lines[src:pos]...)
+				result = append(result, to[skipHead:len(to)-skipTail]...)
+				src = pos + len(old)
+				offset = pos - skipHead - (expected - offset)
+				applied = true
+			}
+		}
+		if !applied {
+			return nil, fmt.Errorf("hunk #%d (@@ -%d,%d +%d,%d @@) does not apply", n+1, hunk.FromLine, hunk.FromCount, hunk.ToLine, hunk.ToCount)
+		}
+	}
+	return append(result, lines[src:]...), nil
+}
+
+// contextLength counts the lines of context at the start of the hunk lines.
+func contextLength(lines []HunkLine) int {
+	n := 0
+	for n < len(lines) && lines[n].Kind == ' ' {
+		n++
+	}
+	return n
+}
+
+func reversed(lines []HunkLine) []HunkLine {
+	r := make([]HunkLine, len(lines))
+	for i, l := range lines {
+		r[len(lines)-1-i] = l
+	}
+	return r
+}
+
+// findLines searches for want in lines[lo:], beginning at the expected
+// position and moving outward, and returns where it's found, or -1.
+func findLines(lines, want []string, lo, expected int) int {
+	hi := len(lines) - len(want)
+	expected = max(lo, min(expected, hi))
+	for delta := 0; expected-delta >= lo || expected+delta <= hi; delta++ {
+		if p := expected - delta; p >= lo && p <= hi && linesMatch(lines[p:], want) {
+			return p
+		}
+		if p := expected + delta; delta > 0 && p >= lo && p <= hi && linesMatch(lines[p:], want) {
+			return p
+		}
+	}
+	return -1
+}
+
+func linesMatch(lines, want []string) bool {
+	for i, w := range want {
+		if lines[i] != w {
+			return false
+		}
+	}
+	return true
+}
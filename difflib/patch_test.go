@@ -0,0 +1,219 @@
+package difflib
+
+import (
+	"strings"
+	"testing"
+)
+
+const gitPatch = `diff --git a/greeting.txt b/greeting.txt
+index 3b18e51..a042389 100644
+--- a/greeting.txt
++++ b/greeting.txt
+@@ -1,3 +1,3 @@ Greetings
+ hello
+-world
++there
+ goodbye
+diff --git a/list.txt b/list.txt
+--- a/list.txt	2020-01-01 00:00:00
++++ b/list.txt	2020-01-02 00:00:00
+@@ -1 +1,2 @@
+ one
++two
+@@ -5,2 +6,2 @@
+ five
+-six
+\ No newline at end of file
++six
+`
+
+func TestParseUnifiedDiff(t *testing.T) {
+	patches, err := ParseUnifiedDiff(gitPatch)
+	assertEqual(t, err, nil)
+	assertEqual(t, patches, []FilePatch{
+		{
+			FromFile: "a/greeting.txt",
+			ToFile:   "b/greeting.txt",
+			Hunks: []Hunk{{
+				FromLine: 1, FromCount: 3, ToLine: 1, ToCount: 3,
+				Section: "Greetings",
+				Lines: []HunkLine{
+					{' ', "hello\n"},
+					{'-', "world\n"},
+					{'+', "there\n"},
+					{' ', "goodbye\n"},
+				},
+			}},
+		},
+		{
+			FromFile: "a/list.txt",
+			FromDate: "2020-01-01 00:00:00",
+			ToFile:   "b/list.txt",
+			ToDate:   "2020-01-02 00:00:00",
+			Hunks: []Hunk{
+				{
+					FromLine: 1, FromCount: 1, ToLine: 1, ToCount: 2,
+					Lines: []HunkLine{
+						{' ', "one\n"},
+						{'+', "two\n"},
+					},
+				},
+				{
+					FromLine: 5, FromCount: 2, ToLine: 6, ToCount: 2,
+					Lines: []HunkLine{
+						{' ', "five\n"},
+						{'-', "six"},
+						{'+', "six\n"},
+					},
+				},
+			},
+		},
+	})
+}
+
+func TestParseUnifiedDiffWithoutHeaders(t *testing.T) {
+	patches, err := ParseUnifiedDiff("@@ -0,0 +1 @@\n+new\n")
+	assertEqual(t, err, nil)
+	assertEqual(t, patches, []FilePatch{{
+		Hunks: []Hunk{{
+			FromLine: 0, FromCount: 0, ToLine: 1, ToCount: 1,
+			Lines: []HunkLine{{'+', "new\n"}},
+		}},
+	}})
+}
+
+func TestParseUnifiedDiffErrors(t *testing.T) {
+	for _, tc := range []struct {
+		text string
+		err  string
+	}{
+		{"@@ -1,2 +1,2 @@\n a\n", "line 3: hunk ends early: 1 lines of the first file and 1 of the second are missing"},
+		{"@@ -1 +1 @@\n-a\n+b\n+c\n", ""},
+		{"@@ -1,2 +1,2 @@\n a\n?b\n", `line 3: unexpected line in hunk: "?b"`},
+		{"@@ -x +1 @@\n", `line 1: malformed hunk header "@@ -x +1 @@"`},
+	} {
+		_, err := ParseUnifiedDiff(tc.text)
+		if tc.err == "" {
+			assertEqual(t, err, nil)
+		} else if err == nil || err.Error() != tc.err {
+			t.Errorf("parsing %q: got error %v, wanted %q", tc.text, err, tc.err)
+		}
+	}
+}
+
+func TestApply(t *testing.T) {
+	patches, err := ParseUnifiedDiff(gitPatch)
+	assertEqual(t, err, nil)
+
+	t.Run("exact", func(t *testing.T) {
+		result, err := Apply(SplitLines("hello\nworld\ngoodbye"), patches[0].Hunks, 0)
+		assertEqual(t, err, nil)
+		assertEqual(t, strings.Join(result, ""), "hello\nthere\ngoodbye\n")
+	})
+	t.Run("file ending in a newline", func(t *testing.T) {
+		lines := strings.SplitAfter("hello\nworld\ngoodbye\n", "\n")
+		lines = lines[:len(lines)-1]
+		result, err := Apply(lines, patches[0].Hunks, 0)
+		assertEqual(t, err, nil)
+		assertEqual(t, result, []string{"hello\n", "there\n", "goodbye\n"})
+	})
+	t.Run("no newline at end of file", func(t *testing.T) {
+		lines := strings.SplitAfter("one\nthree\nfour\nfive\nsix", "\n")
+		result, err := Apply(lines, patches[1].Hunks, 0)
+		assertEqual(t, err, nil)
+		assertEqual(t, strings.Join(result, ""), "one\ntwo\nthree\nfour\nfive\nsix\n")
+	})
+	t.Run("offset", func(t *testing.T) {
+		lines := SplitLines("preamble\nmore preamble\nhello\nworld\ngoodbye")
+		result, err := Apply(lines, patches[0].Hunks, 0)
+		assertEqual(t, err, nil)
+		assertEqual(t, strings.Join(result, ""), "preamble\nmore preamble\nhello\nthere\ngoodbye\n")
+	})
+	t.Run("offset carries over to later hunks", func(t *testing.T) {
+		hunks := []Hunk{
+			{FromLine: 2, FromCount: 1, ToLine: 2, ToCount: 1, Lines: []HunkLine{{'-', "x\n"}, {'+', "X\n"}}},
+			{FromLine: 4, FromCount: 1, ToLine: 4, ToCount: 1, Lines: []HunkLine{{'-', "x\n"}, {'+', "Y\n"}}},
+		}
+		// The first hunk matches two lines later than stated, so the second
+		// should too -- even though there's an "x" right where it says.
+		lines := SplitLines("a\nb\nc\nx\nd\nx\ne\nx")
+		result, err := Apply(lines, hunks, 0)
+		assertEqual(t, err, nil)
+		assertEqual(t, strings.Join(result, ""), "a\nb\nc\nX\nd\nY\ne\nx\n")
+	})
+	t.Run("fuzz", func(t *testing.T) {
+		lines := SplitLines("hi\nworld\ngoodbye")
+		_, err := Apply(lines, patches[0].Hunks, 0)
+		assertEqual(t, err.Error(), "hunk #1 (@@ -1,3 +1,3 @@) does not apply")
+		result, err := Apply(lines, patches[0].Hunks, 1)
+		assertEqual(t, err, nil)
+		assertEqual(t, strings.Join(result, ""), "hi\nthere\ngoodbye\n")
+	})
+	t.Run("mismatch", func(t *testing.T) {
+		_, err := Apply(SplitLines("hello\nearth\ngoodbye"), patches[0].Hunks, 2)
+		assertEqual(t, err.Error(), "hunk #1 (@@ -1,3 +1,3 @@) does not apply")
+	})
+	t.Run("insertion into empty file", func(t *testing.T) {
+		hunks := []Hunk{{FromLine: 0, FromCount: 0, ToLine: 1, ToCount: 1, Lines: []HunkLine{{'+', "new\n"}}}}
+		result, err := Apply(nil, hunks, 0)
+		assertEqual(t, err, nil)
+		assertEqual(t, result, []string{"new\n"})
+	})
+}
+
+func TestFormatPOSIXRoundTrip(t *testing.T) {
+	a := strings.SplitAfter("one\ntwo\nthree\nfour\nfive\nsix\nseven\neight", "\n")
+	b := strings.SplitAfter("zero\none\nthree\nfour\nfive\nsix\nseven\nEIGHT\nnine", "\n")
+	text, err := GetUnifiedDiffString(UnifiedDiff{
+		A: a, B: b,
+		FromFile: "a.txt", ToFile: "b.txt",
+		Context: 1,
+		Format:  FormatPOSIX,
+	})
+	assertEqual(t, err, nil)
+	assertEqual(t, text, strings.Join([]string{
+		"--- a.txt\n",
+		"+++ b.txt\n",
+		"@@ -1,3 +1,3 @@\n",
+		"+zero\n",
+		" one\n",
+		"-two\n",
+		" three\n",
+		"@@ -7,2 +7,3 @@\n",
+		" seven\n",
+		"-eight\n",
+		"\\ No newline at end of file\n",
+		"+EIGHT\n",
+		"+nine\n",
+		"\\ No newline at end of file\n",
+	}, ""))
+
+	patches, err := ParseUnifiedDiff(text)
+	assertEqual(t, err, nil)
+	assertEqual(t, len(patches), 1)
+	result, err := Apply(a, patches[0].Hunks, 0)
+	assertEqual(t, err, nil)
+	assertEqual(t, result, b)
+}
+
+func TestFormatPOSIXEol(t *testing.T) {
+	text, err := GetUnifiedDiffString(UnifiedDiff{
+		A:        []string{"one\r\n", "two"},
+		B:        []string{"one\r\n", "three"},
+		FromFile: "a.txt", ToFile: "b.txt",
+		Eol:     "\r\n",
+		Context: 1,
+		Format:  FormatPOSIX,
+	})
+	assertEqual(t, err, nil)
+	assertEqual(t, text, strings.Join([]string{
+		"--- a.txt\r\n",
+		"+++ b.txt\r\n",
+		"@@ -1,2 +1,2 @@\r\n",
+		" one\r\n",
+		"-two\r\n",
+		"\\ No newline at end of file\r\n",
+		"+three\r\n",
+		"\\ No newline at end of file\r\n",
+	}, ""))
+}
@@ -0,0 +1,184 @@
+package wish
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/warpfork/go-wish/cmp"
+)
+
+var (
+	_ Checker = ShouldWrap
+	_ Checker = ShouldBeErrorAs
+	_ Checker = ShouldHaveErrorMessage
+)
+
+var errorType = reflect.TypeOf((*error)(nil)).Elem()
+
+// ShouldWrap asserts that the actual value is an error which is, or wraps,
+// the desired error, with the same semantics as `errors.Is`.
+// This means the entire chain of wrapped errors is examined, including
+// the branches of errors which wrap several others (by implementing
+// `Unwrap() []error`).
+//
+// The desire must be an error, or nil (in which case only a nil error passes).
+//
+// If the check is rejected, the message shows the entire chain of errors
+// that was searched, including the concrete type of each layer.
+func ShouldWrap(actual interface{}, desire interface{}) (problem string, passed bool) {
+	desireErr, ok := desire.(error)
+	if !ok && desire != nil {
+		panic("ShouldWrap must be used with an error as the desired value")
+	}
+	actualErr, problem := asError(actual)
+	if problem != "" {
+		return problem, false
+	}
+	if isNilError(actualErr) {
+		// Its methods can't be trusted not to panic, so don't search its chain.
+		if reflect.TypeOf(actualErr) == reflect.TypeOf(desireErr) && isNilError(desireErr) {
+			return "", true
+		}
+		if desireErr == nil {
+			return fmt.Sprintf("wanted nil, but got %s", describeError(actualErr)), false
+		}
+		return fmt.Sprintf("got %s; wanted an error wrapping %s", describeError(actualErr), describeError(desireErr)), false
+	}
+	if errors.Is(actualErr, desireErr) {
+		return "", true
+	}
+	if desireErr == nil {
+		return "wanted nil, but got an error:\n" + Indent(describeErrorChain(actualErr)), false
+	}
+	if actualErr == nil {
+		return fmt.Sprintf("got nil; wanted an error wrapping %s", describeError(desireErr)), false
+	}
+	return fmt.Sprintf("no error in the chain matches %s; the chain is:\n%s", describeError(desireErr), Indent(describeErrorChain(actualErr))), false
+}
+
+// ShouldBeErrorAs asserts that the actual value is an error with some error
+// in its chain which has the same type as the desired value, using the same
+// semantics as `errors.As`; and then compares that error to the desired
+// value, using the same semantics as ShouldEqual.
+//
+// For example:
+//
+//	Wish(t, err, ShouldBeErrorAs, &os.PathError{Op: "open", Path: "nope", Err: syscall.ENOENT})
+//
+// The desire must be a value of a type that implements error.
+//
+// If no error of the right type is found, the rejection message shows the
+// entire chain of errors that was searched, including the concrete type of
+// each layer.
+func ShouldBeErrorAs(actual interface{}, desire interface{}) (problem string, passed bool) {
+	if desire == nil || !reflect.TypeOf(desire).Implements(errorType) {
+		panic("ShouldBeErrorAs must be used with a value of an error type as the desired value")
+	}
+	actualErr, problem := asError(actual)
+	if problem != "" {
+		return problem, false
+	}
+	rt_desire := reflect.TypeOf(desire)
+	if actualErr == nil {
+		return "got nil; wanted an error of type " + rt_desire.String(), false
+	}
+	if isNilError(actualErr) {
+		return fmt.Sprintf("got %s; wanted an error of type %s", describeError(actualErr), rt_desire), false
+	}
+	target := reflect.New(rt_desire)
+	if !errors.As(actualErr, target.Interface()) {
+		return fmt.Sprintf("no error in the chain has type %s; the chain is:\n%s", rt_desire, Indent(describeErrorChain(actualErr))), false
+	}
+	if diff := cmp.Diff(target.Elem().Interface(), desire); diff != "" {
+		return fmt.Sprintf("found an error of type %s in the chain, but it differs:\n%s", rt_desire, Indent(diff)), false
+	}
+	return "", true
+}
+
+// ShouldHaveErrorMessage asserts that the actual value is an error, and that
+// the result of calling its Error method equals the desired string.
+// Mismatches are reported with the same diff as ShouldEqual uses for strings.
+func ShouldHaveErrorMessage(actual interface{}, desire interface{}) (problem string, passed bool) {
+	desireMsg, ok := desire.(string)
+	if !ok {
+		panic("ShouldHaveErrorMessage must be used with a string as the desired value")
+	}
+	actualErr, problem := asError(actual)
+	if problem != "" {
+		return problem, false
+	}
+	if actualErr == nil {
+		return fmt.Sprintf("got nil; wanted an error with message %q", desireMsg), false
+	}
+	if isNilError(actualErr) {
+		return fmt.Sprintf("got %s; wanted an error with message %q", describeError(actualErr), desireMsg), false
+	}
+	return ShouldEqual(actualErr.Error(), desireMsg)
+}
+
+// asError converts the actual value of a check to an error, or returns
+// a problem description if it's neither nil nor an error.
+func asError(actual interface{}) (error, string) {
+	if actual == nil {
+		return nil, ""
+	}
+	err, ok := actual.(error)
+	if !ok {
+		return nil, fmt.Sprintf("got value of type %T, which is not an error", actual)
+	}
+	return err, ""
+}
+
+// describeError renders one error as its concrete type and its message.
+// A typed nil (such as a nil *MyErr in an error interface) is rendered
+// as its type, without calling its Error method, which would likely panic.
+func describeError(err error) string {
+	if isNilError(err) {
+		return fmt.Sprintf("%T(nil)", err)
+	}
+	return fmt.Sprintf("%T(%q)", err, err.Error())
+}
+
+// isNilError reports whether err is a non-nil interface holding a nil value.
+func isNilError(err error) bool {
+	if err == nil {
+		return false
+	}
+	rv := reflect.ValueOf(err)
+	switch rv.Kind() {
+	case reflect.Ptr, reflect.Map, reflect.Slice, reflect.Func, reflect.Chan, reflect.Interface:
+		return rv.IsNil()
+	}
+	return false
+}
+
+// describeErrorChain renders an error and every error it wraps, one per line,
+// indented one more level for each layer of wrapping.
+func describeErrorChain(err error) string {
+	var sb strings.Builder
+	var walk func(err error, depth int)
+	walk = func(err error, depth int) {
+		sb.WriteString(strings.Repeat("\t", depth))
+		sb.WriteString(describeError(err))
+		sb.WriteByte('\n')
+		if isNilError(err) {
+			return
+		}
+		switch err := err.(type) {
+		case interface{ Unwrap() error }:
+			if inner := err.Unwrap(); inner != nil {
+				walk(inner, depth+1)
+			}
+		case interface{ Unwrap() []error }:
+			for _, inner := range err.Unwrap() {
+				if inner != nil {
+					walk(inner, depth+1)
+				}
+			}
+		}
+	}
+	walk(err, 0)
+	return sb.String()
+}
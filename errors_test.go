@@ -0,0 +1,103 @@
+package wish
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+type codedError struct {
+	Code int
+}
+
+func (e *codedError) Error() string { return fmt.Sprintf("code %d", e.Code) }
+
+func TestShouldWrap(t *testing.T) {
+	sentinel := errors.New("sentinel")
+	t.Run("direct match", func(t *testing.T) {
+		msg, passed := ShouldWrap(sentinel, sentinel)
+		Wish(t, passed, ShouldEqual, true)
+		shouldStringMatch(t, msg, "")
+	})
+	t.Run("wrapped match", func(t *testing.T) {
+		_, passed := ShouldWrap(fmt.Errorf("ctx: %w", sentinel), sentinel)
+		Wish(t, passed, ShouldEqual, true)
+	})
+	t.Run("match inside a multi-error", func(t *testing.T) {
+		_, passed := ShouldWrap(errors.Join(errors.New("other"), fmt.Errorf("ctx: %w", sentinel)), sentinel)
+		Wish(t, passed, ShouldEqual, true)
+	})
+	t.Run("no match", func(t *testing.T) {
+		msg, passed := ShouldWrap(errors.Join(errors.New("a"), fmt.Errorf("ctx: %w", &codedError{4})), sentinel)
+		Wish(t, passed, ShouldEqual, false)
+		shouldStringMatch(t, msg, Dedent(`
+			no error in the chain matches *errors.errorString("sentinel"); the chain is:
+				*errors.joinError("a\nctx: code 4")
+					*errors.errorString("a")
+					*fmt.wrapError("ctx: code 4")
+						*wish.codedError("code 4")
+		`))
+	})
+	t.Run("nil actual", func(t *testing.T) {
+		msg, _ := ShouldWrap(nil, sentinel)
+		shouldStringMatch(t, msg, `got nil; wanted an error wrapping *errors.errorString("sentinel")`)
+	})
+	t.Run("typed nil actual", func(t *testing.T) {
+		var err error = (*codedError)(nil)
+		msg, _ := ShouldWrap(err, sentinel)
+		shouldStringMatch(t, msg, `got *wish.codedError(nil); wanted an error wrapping *errors.errorString("sentinel")`)
+		msg, _ = ShouldWrap(err, nil)
+		shouldStringMatch(t, msg, `wanted nil, but got *wish.codedError(nil)`)
+		_, passed := ShouldWrap(err, err)
+		Wish(t, passed, ShouldEqual, true)
+	})
+	t.Run("not an error", func(t *testing.T) {
+		msg, _ := ShouldWrap("str", sentinel)
+		shouldStringMatch(t, msg, `got value of type string, which is not an error`)
+	})
+}
+
+func TestShouldBeErrorAs(t *testing.T) {
+	t.Run("match", func(t *testing.T) {
+		msg, passed := ShouldBeErrorAs(fmt.Errorf("ctx: %w", &codedError{4}), &codedError{4})
+		Wish(t, passed, ShouldEqual, true)
+		shouldStringMatch(t, msg, "")
+	})
+	t.Run("type found but fields differ", func(t *testing.T) {
+		msg, _ := ShouldBeErrorAs(fmt.Errorf("ctx: %w", &codedError{4}), &codedError{5})
+		shouldStringMatch(t, msg, Dedent(`
+			found an error of type *wish.codedError in the chain, but it differs:
+				  &wish.codedError{
+				- 	Code: 4,
+				+ 	Code: 5,
+				  }
+		`))
+	})
+	t.Run("type not found", func(t *testing.T) {
+		msg, _ := ShouldBeErrorAs(fmt.Errorf("ctx: %w", errors.New("inner")), &codedError{5})
+		shouldStringMatch(t, msg, Dedent(`
+			no error in the chain has type *wish.codedError; the chain is:
+				*fmt.wrapError("ctx: inner")
+					*errors.errorString("inner")
+		`))
+	})
+	t.Run("typed nil actual", func(t *testing.T) {
+		msg, _ := ShouldBeErrorAs(error((*codedError)(nil)), &codedError{5})
+		shouldStringMatch(t, msg, `got *wish.codedError(nil); wanted an error of type *wish.codedError`)
+	})
+}
+
+func TestShouldHaveErrorMessage(t *testing.T) {
+	_, passed := ShouldHaveErrorMessage(errors.New("boom"), "boom")
+	Wish(t, passed, ShouldEqual, true)
+	msg, _ := ShouldHaveErrorMessage(errors.New("boom"), "bang")
+	shouldStringMatch(t, msg, Dedent(`
+		@@ -1 +1 @@
+		- boom
+		+ bang
+	`))
+	msg, _ = ShouldHaveErrorMessage(nil, "bang")
+	shouldStringMatch(t, msg, `got nil; wanted an error with message "bang"`)
+	msg, _ = ShouldHaveErrorMessage(error((*codedError)(nil)), "bang")
+	shouldStringMatch(t, msg, `got *wish.codedError(nil); wanted an error with message "bang"`)
+}
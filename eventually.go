@@ -0,0 +1,143 @@
+package wish
+
+import (
+	"fmt"
+	"time"
+)
+
+// deadliner is implemented by `*testing.T` (since go1.15).
+// It's not part of the T interface, so that other implementations of T
+// needn't bother; if it's present, wish uses it to avoid overrunning
+// the test binary's timeout.
+type deadliner interface {
+	Deadline() (deadline time.Time, ok bool)
+}
+
+// Eventually repeatedly evaluates the actual func and makes an assertion
+// like Wish does, passing as soon as the check passes once.
+// Between attempts, it sleeps for the interval; if the timeout expires without
+// the check passing, the test fails, and the last rejection message is logged
+// together with how many attempts were made.
+//
+// Eventually is intended for testing asynchronous code, in place of writing
+// sleep loops around Wish.  Any Checker (and any Options) may be used.
+//
+// If T has a deadline (as `*testing.T` does when `go test -timeout` is in
+// effect), the timeout is shortened if necessary, so that Eventually gives
+// up and reports its rejection before the whole test binary is killed.
+//
+// The interval must be positive; Eventually panics otherwise.
+//
+// Like Wish, failure does *not* cause FailNow; execution will continue.
+func Eventually(t T, actual func() interface{}, check Checker, desired interface{}, timeout, interval time.Duration, opts ...Option) bool {
+	t.Helper()
+	if interval <= 0 {
+		panic("Eventually interval must be positive")
+	}
+	site := here(argsWithT)
+	cfg := buildConfig(opts)
+	configured := cfg.configure(check)
+	timeout, cut := capToDeadline(t, timeout)
+	start := time.Now()
+	for attempts := 1; ; attempts++ {
+		value := actual()
+		problemMsg, passed := configured(value, desired)
+		if passed {
+			return true
+		}
+		elapsed := time.Since(start)
+		if elapsed >= timeout {
+			recordRejection(site, t, getCheckerShortName(check), value, desired, problemMsg, cfg, false)
+			headline := fmt.Sprintf("%s%s check still rejected after %s over %s%s; last rejection was",
+				getCheckerShortName(check), site.describe(), pluralize(attempts, "attempt"), elapsed.Round(time.Millisecond), cut)
+			t.Log(cfg.formatRejection(headline, problemMsg))
+			t.Fail()
+			return false
+		}
+		time.Sleep(minDuration(interval, timeout-elapsed))
+	}
+}
+
+// Consistently repeatedly evaluates the actual func and makes an assertion
+// like Wish does, for the whole duration, sleeping for the interval between
+// attempts.  The check must pass every time; the first rejection fails
+// the test, and is logged together with the attempt number and time at which
+// it occurred.
+//
+// As with Eventually, the duration is shortened if necessary to respect
+// the deadline of T, and the interval must be positive.  If the duration was
+// shortened, Consistently still passes, but logs a note saying how long
+// the check was actually made for.
+//
+// Like Wish, failure does *not* cause FailNow; execution will continue.
+func Consistently(t T, actual func() interface{}, check Checker, desired interface{}, duration, interval time.Duration, opts ...Option) bool {
+	t.Helper()
+	if interval <= 0 {
+		panic("Consistently interval must be positive")
+	}
+	site := here(argsWithT)
+	cfg := buildConfig(opts)
+	configured := cfg.configure(check)
+	requested := duration
+	duration, cut := capToDeadline(t, duration)
+	start := time.Now()
+	for attempts := 1; ; attempts++ {
+		value := actual()
+		problemMsg, passed := configured(value, desired)
+		elapsed := time.Since(start)
+		if !passed {
+			recordRejection(site, t, getCheckerShortName(check), value, desired, problemMsg, cfg, false)
+			headline := fmt.Sprintf("%s%s check rejected on attempt %d, after %s",
+				getCheckerShortName(check), site.describe(), attempts, elapsed.Round(time.Millisecond))
+			t.Log(cfg.formatRejection(headline, problemMsg))
+			t.Fail()
+			return false
+		}
+		if elapsed >= duration {
+			if cut != "" {
+				t.Log(fmt.Sprintf("%s%s check passed %s over %s of the %s asked for%s",
+					getCheckerShortName(check), site.describe(), pluralize(attempts, "time"), elapsed.Round(time.Millisecond), requested, cut))
+			}
+			return true
+		}
+		time.Sleep(minDuration(interval, duration-elapsed))
+	}
+}
+
+// capToDeadline shortens the timeout, if T has a deadline which would arrive
+// first.  A margin is left before the deadline, so there's time to report
+// the outcome.  If the timeout was shortened, a note explaining so is
+// returned (otherwise the note is empty).
+func capToDeadline(t T, timeout time.Duration) (time.Duration, string) {
+	d, ok := t.(deadliner)
+	if !ok {
+		return timeout, ""
+	}
+	deadline, ok := d.Deadline()
+	if !ok {
+		return timeout, ""
+	}
+	remaining := time.Until(deadline)
+	remaining -= remaining / 20
+	if remaining >= timeout {
+		return timeout, ""
+	}
+	if remaining < 0 {
+		remaining = 0
+	}
+	return remaining, " (cut short by the test deadline)"
+}
+
+func minDuration(a, b time.Duration) time.Duration {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func pluralize(n int, noun string) string {
+	if n == 1 {
+		return "1 " + noun
+	}
+	return fmt.Sprintf("%d %ss", n, noun)
+}
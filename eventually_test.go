@@ -0,0 +1,89 @@
+package wish
+
+import (
+	"regexp"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// deadlineT is a recordingT with a deadline.
+type deadlineT struct {
+	recordingT
+	deadline time.Time
+}
+
+func (t *deadlineT) Deadline() (time.Time, bool) { return t.deadline, true }
+
+func TestEventually(t *testing.T) {
+	t.Run("passes once the value arrives", func(t *testing.T) {
+		var n int32
+		rt := &recordingT{}
+		passed := Eventually(rt, func() interface{} { return atomic.AddInt32(&n, 1) }, ShouldEqual, int32(3), time.Second, time.Millisecond)
+		Wish(t, passed, ShouldEqual, true)
+		Wish(t, rt.failed, ShouldEqual, false)
+	})
+	t.Run("reports the last rejection", func(t *testing.T) {
+		rt := &recordingT{}
+		passed := Eventually(rt, func() interface{} { return "nope" }, ShouldEqual, "yes", 20*time.Millisecond, 5*time.Millisecond)
+		Wish(t, passed, ShouldEqual, false)
+		Wish(t, rt.failed, ShouldEqual, true)
+		Wish(t, rt.output(), ShouldMatchPattern, Dedent(`
+			ShouldEqual(func() interface{} { return "nope" }, "yes") check still rejected after {{\d+}} attempts over {{\d+}}ms; last rejection was:
+				@@ -1 +1 @@
+				- nope
+				+ yes
+		`))
+	})
+	t.Run("respects the test deadline", func(t *testing.T) {
+		rt := &deadlineT{deadline: time.Now().Add(20 * time.Millisecond)}
+		start := time.Now()
+		Eventually(rt, func() interface{} { return 1 }, ShouldEqual, 2, time.Minute, 5*time.Millisecond)
+		if time.Since(start) > 10*time.Second {
+			t.Errorf("should have given up near the deadline")
+		}
+		if !regexp.MustCompile(`\(cut short by the test deadline\)`).MatchString(rt.output()) {
+			t.Errorf("should note the deadline:\n%s", rt.output())
+		}
+	})
+	t.Run("rejects a non-positive interval", func(t *testing.T) {
+		defer func() {
+			shouldStringMatch(t, recover().(string), "Eventually interval must be positive")
+		}()
+		Eventually(&recordingT{}, func() interface{} { return 1 }, ShouldEqual, 2, time.Second, 0)
+	})
+}
+
+func TestConsistently(t *testing.T) {
+	t.Run("passes if the value holds", func(t *testing.T) {
+		rt := &recordingT{}
+		passed := Consistently(rt, func() interface{} { return 1 }, ShouldEqual, 1, 20*time.Millisecond, 5*time.Millisecond)
+		Wish(t, passed, ShouldEqual, true)
+	})
+	t.Run("rejects as soon as the value changes", func(t *testing.T) {
+		var n int32
+		rt := &recordingT{}
+		passed := Consistently(rt, func() interface{} { return atomic.AddInt32(&n, 1) < 3 }, ShouldEqual, true, time.Second, time.Millisecond)
+		Wish(t, passed, ShouldEqual, false)
+		Wish(t, rt.output(), ShouldMatchPattern, Dedent(`
+			ShouldEqual(func() interface{} { return atomic.AddInt32(&n, 1) < 3 }, true) check rejected on attempt 3, after {{\d+}}ms:
+				  bool(
+				- 	false,
+				+ 	true,
+				  )
+		`))
+	})
+	t.Run("notes a duration cut short by the test deadline", func(t *testing.T) {
+		rt := &deadlineT{deadline: time.Now().Add(20 * time.Millisecond)}
+		passed := Consistently(rt, func() interface{} { return 1 }, ShouldEqual, 1, time.Minute, 5*time.Millisecond)
+		Wish(t, passed, ShouldEqual, true)
+		Wish(t, rt.failed, ShouldEqual, false)
+		Wish(t, rt.output(), ShouldMatchPattern, "ShouldEqual(func() interface{} { return 1 }, 1) check passed {{\\d+ times?}} over {{\\d+}}ms of the 1m0s asked for (cut short by the test deadline)")
+	})
+	t.Run("rejects a non-positive interval", func(t *testing.T) {
+		defer func() {
+			shouldStringMatch(t, recover().(string), "Consistently interval must be positive")
+		}()
+		Consistently(&recordingT{}, func() interface{} { return 1 }, ShouldEqual, 1, time.Second, -time.Millisecond)
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/warpfork/go-wish"
+	"github.com/warpfork/go-wish/cmp/cmpopts"
 )
 
 // fakeT is a dummy replacement for `*testing.T` which simply always prints
@@ -25,7 +26,7 @@ func ExampleThing() {
 	fmt.Printf("%v\n", wish.Wish(t, actual, wish.ShouldEqual, objective))
 
 	// Output:
-	// ShouldEqual check rejected:
+	// ShouldEqual(actual, objective) check rejected:
 	// 	@@ -1 +1 @@
 	// 	- foobar
 	// 	+ bazfomp
@@ -39,11 +40,11 @@ func ExampleMultilineString() {
 	fmt.Printf("%v\n", wish.Wish(t, actual, wish.ShouldEqual, objective))
 
 	// Output:
-	// ShouldEqual check rejected:
+	// ShouldEqual(actual, objective) check rejected:
 	// 	@@ -1,3 +1,3 @@
-	// 	- foobar\n
-	// 	+ bazfomp\n
-	// 	  woop\n
+	// 	- foobar
+	// 	+ bazfomp
+	// 	  woop
 	// 	- wow
 	// 	+ wowdiff
 	// false
@@ -66,7 +67,7 @@ func ExampleWish_ShouldEqual_CompareStructsReject() {
 	fmt.Printf("%v\n", wish.Wish(t, actual, wish.ShouldEqual, objective))
 
 	// Output:
-	// ShouldEqual check rejected:
+	// ShouldEqual(actual, objective) check rejected:
 	// 	  interface{}(
 	// 	- 	struct{ Bar string }{Bar: "asdf"},
 	// 	+ 	struct{ Baz string }{Baz: "qwer"},
@@ -81,10 +82,40 @@ func ExampleWish_ShouldEqual_TypeMismatch() {
 	fmt.Printf("%v\n", wish.Wish(t, actual, wish.ShouldEqual, objective))
 
 	// Output:
-	// ShouldEqual check rejected:
+	// ShouldEqual(actual, objective) check rejected:
 	// 	  interface{}(
 	// 	- 	string("foobar"),
 	// 	+ 	struct{}{},
 	// 	  )
 	// false
 }
+
+func ExampleCmpOptions() {
+	t := &fakeT{}
+	type record struct {
+		Name    string
+		Created int64
+	}
+	actual := record{"asdf", 1234}
+	objective := record{"asdf", 0}
+	fmt.Printf("%v\n", wish.Wish(t, actual, wish.ShouldEqual, objective,
+		wish.CmpOptions(cmpopts.IgnoreFields(record{}, "Created")),
+	))
+
+	// Output:
+	// true
+}
+
+func ExampleMessagef() {
+	t := &fakeT{}
+	fmt.Printf("%v\n", wish.Wish(t, "foobar", wish.ShouldEqual, "bazfomp",
+		wish.Messagef("row %d", 4),
+	))
+
+	// Output:
+	// ShouldEqual check rejected (row 4):
+	// 	@@ -1 +1 @@
+	// 	- foobar
+	// 	+ bazfomp
+	// false
+}
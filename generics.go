@@ -0,0 +1,90 @@
+package wish
+
+import (
+	"reflect"
+)
+
+// CheckerOf is the set of checker function types which can be used with Check
+// for values of type V: either a function of the same shape as Checker, but
+// typed for V (such as ShouldEqualOf[V]), or any plain Checker.
+type CheckerOf[V any] interface {
+	~func(actual V, desire V) (problem string, passed bool) |
+		~func(actual interface{}, desire interface{}) (problem string, passed bool)
+}
+
+// Check is the same as Wish, except the actual and desired values must be of
+// the same type, which is checked at compile time.
+//
+// This is most useful with untyped constants: for example,
+// `Wish(t, int64(5), ShouldEqual, 5)` rejects (comparing an int64 to an int),
+// whereas `Check(t, int64(5), ShouldEqual, 5)` passes, because the 5 is
+// converted to int64 by the compiler.
+//
+// The check may be any Checker, or a typed checker function like
+// ShouldEqualOf.  (Note that checker-specific Options, like CmpOptions, can
+// only be used with the plain Checkers.)
+func Check[V any, C CheckerOf[V]](t T, actual V, check C, desired V, opts ...Option) bool {
+	t.Helper()
+	return assert(here(argsWithT), t, actual, untypedChecker[V](check), getFuncShortName(check), desired, opts, false)
+}
+
+// RequireCheck is the same as Require, except the actual and desired values
+// must be of the same type, which is checked at compile time.  See Check.
+func RequireCheck[V any, C CheckerOf[V]](t T, actual V, check C, desired V, opts ...Option) {
+	t.Helper()
+	assert(here(argsWithT), t, actual, untypedChecker[V](check), getFuncShortName(check), desired, opts, true)
+}
+
+var checkerType = reflect.TypeOf(Checker(nil))
+
+// untypedChecker converts any of the kinds of checker function allowed by
+// CheckerOf into a Checker.  Plain Checkers are returned as-is (so that
+// options and names still work as usual for them).
+func untypedChecker[V any, C CheckerOf[V]](check C) Checker {
+	rv := reflect.ValueOf(check)
+	if rv.Type().ConvertibleTo(checkerType) {
+		return rv.Convert(checkerType).Interface().(Checker)
+	}
+	typed := rv.Convert(reflect.TypeOf((func(V, V) (string, bool))(nil))).Interface().(func(V, V) (string, bool))
+	return Untyped(typed)
+}
+
+// Untyped turns a typed checker function into a Checker, so it can be used
+// with Wish, AllOf, and anything else that takes a Checker.
+// The returned Checker rejects (rather than panics) if given values of
+// the wrong types.  (Since the result is a closure, Wish will report it under
+// the name "Untyped"; Check doesn't need Untyped, and reports the real name.)
+func Untyped[V any](check func(actual V, desire V) (problem string, passed bool)) Checker {
+	return func(actual interface{}, desire interface{}) (string, bool) {
+		a, ok := actual.(V)
+		if !ok && actual != nil {
+			return ShouldBeSameTypeAs(actual, *new(V))
+		}
+		d, ok := desire.(V)
+		if !ok && desire != nil {
+			return ShouldBeSameTypeAs(desire, *new(V))
+		}
+		return check(a, d)
+	}
+}
+
+// ShouldEqualOf is a typed version of ShouldEqual.
+func ShouldEqualOf[V any](actual V, desire V) (diff string, eq bool) {
+	return ShouldEqual(actual, desire)
+}
+
+// ShouldEqualUnorderedOf is a typed version of ShouldEqualUnordered.
+func ShouldEqualUnorderedOf[E any](actual []E, desire []E) (problem string, passed bool) {
+	return ShouldEqualUnordered(actual, desire)
+}
+
+// ShouldMatchSubsetOf is a typed version of ShouldMatchSubset.
+func ShouldMatchSubsetOf[V any](actual V, desire V) (diff string, eq bool) {
+	return ShouldMatchSubset(actual, desire)
+}
+
+// ShouldBeSameTypeOf is a typed version of ShouldBeSameTypeAs, which is
+// mostly useful with interface types, e.g. ShouldBeSameTypeOf[error].
+func ShouldBeSameTypeOf[V any](actual V, desire V) (diff string, eq bool) {
+	return ShouldBeSameTypeAs(actual, desire)
+}
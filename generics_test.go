@@ -0,0 +1,43 @@
+package wish
+
+import (
+	"testing"
+)
+
+func TestCheck(t *testing.T) {
+	t.Run("untyped constants take the actual type", func(t *testing.T) {
+		rt := &recordingT{}
+		Wish(t, Check(rt, int64(5), ShouldEqual, 5), ShouldEqual, true)
+		Wish(t, Wish(rt, int64(5), ShouldEqual, 5), ShouldEqual, false)
+	})
+	t.Run("typed checker", func(t *testing.T) {
+		rt := &recordingT{}
+		Wish(t, Check(rt, []int{1, 2}, ShouldEqualOf[[]int], []int{1, 3}), ShouldEqual, false)
+		shouldStringMatch(t, rt.output(), Dedent(`
+			ShouldEqualOf check rejected:
+				  []int{
+				  	1,
+				- 	2,
+				+ 	3,
+				  }
+		`))
+	})
+	t.Run("plain checker keeps its name and options", func(t *testing.T) {
+		rt := &recordingT{}
+		Check(rt, "a\nb\nc", ShouldEqual, "a\nb\nX", ContextLines(0))
+		shouldStringMatch(t, rt.output(), Dedent(`
+			ShouldEqual check rejected:
+				@@ -3 +3 @@
+				- c
+				+ X
+		`))
+	})
+}
+
+func TestUntyped(t *testing.T) {
+	check := Untyped(ShouldEqualUnorderedOf[int])
+	_, passed := check([]int{1, 2}, []int{2, 1})
+	Wish(t, passed, ShouldEqual, true)
+	msg, _ := check([]string{"a"}, []int{1})
+	shouldStringMatch(t, msg, "got value of type []string; wanted a value of type []int")
+}
@@ -0,0 +1,142 @@
+package wish
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/warpfork/go-wish/cmp"
+)
+
+var (
+	_ Checker = ShouldEqualJSON
+)
+
+// ShouldEqualJSON asserts that two values are JSON documents which are
+// semantically equal: key order and whitespace are irrelevant, and numbers
+// are compared by their exact value (so `1`, `1.0`, and `1e0` are equal, and
+// large integers are never rounded through a float64 and confused).
+//
+// Both values may be a string, a []byte, or a json.RawMessage.
+// Any other value is first serialized with encoding/json.
+//
+// If the check is rejected, the rejection message lists each difference,
+// labeled by its location in the document as a JSON Pointer (RFC 6901),
+// with the actual and desired values at that location rendered as JSON.
+func ShouldEqualJSON(actual interface{}, desire interface{}) (problem string, passed bool) {
+	desireDoc, err := decodeJSONDocument(desire)
+	if err != nil {
+		panic(fmt.Sprintf("ShouldEqualJSON must be used with valid JSON as the desired value: %s", err))
+	}
+	actualDoc, err := decodeJSONDocument(actual)
+	if err != nil {
+		return fmt.Sprintf("actual value is not valid JSON: %s", err), false
+	}
+	var reporter jsonDiffReporter
+	if cmp.Equal(actualDoc, desireDoc, jsonNumberComparer, cmp.Reporter(&reporter)) {
+		return "", true
+	}
+	return reporter.sb.String(), false
+}
+
+// decodeJSONDocument parses a JSON document into generic values, keeping
+// numbers as json.Number so that no precision is lost.
+func decodeJSONDocument(v interface{}) (interface{}, error) {
+	var raw []byte
+	switch v := v.(type) {
+	case string:
+		raw = []byte(v)
+	case []byte:
+		raw = v
+	case json.RawMessage:
+		raw = v
+	default:
+		var err error
+		if raw, err = json.Marshal(v); err != nil {
+			return nil, err
+		}
+	}
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	var doc interface{}
+	if err := dec.Decode(&doc); err != nil {
+		return nil, err
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return nil, fmt.Errorf("unexpected content after the end of the document")
+	}
+	return doc, nil
+}
+
+// jsonNumberComparer compares JSON numbers by their exact rational value.
+var jsonNumberComparer = cmp.Comparer(func(x, y json.Number) bool {
+	rx, okx := new(big.Rat).SetString(string(x))
+	ry, oky := new(big.Rat).SetString(string(y))
+	if !okx || !oky {
+		return x == y
+	}
+	return rx.Cmp(ry) == 0
+})
+
+// jsonDiffReporter is a cmp.Reporter which describes each unequal leaf of a
+// pair of decoded JSON documents, as it is found.
+type jsonDiffReporter struct {
+	path cmp.Path
+	sb   strings.Builder
+}
+
+func (r *jsonDiffReporter) PushStep(ps cmp.PathStep) { r.path = append(r.path, ps) }
+func (r *jsonDiffReporter) PopStep()                 { r.path = r.path[:len(r.path)-1] }
+func (r *jsonDiffReporter) Report(rs cmp.Result) {
+	if rs.Equal() {
+		return
+	}
+	vx, vy := r.path.Last().Values()
+	fmt.Fprintf(&r.sb, "%s:\n\t- %s\n\t+ %s\n", jsonPointer(r.path), renderJSONValue(vx), renderJSONValue(vy))
+}
+
+// jsonPointer renders the location of a cmp.Path within a decoded JSON
+// document as a JSON Pointer.  The pointer to the whole document is
+// the empty string, which would be confusing to read, so it's rendered
+// as "(document root)" instead.
+func jsonPointer(p cmp.Path) string {
+	var sb strings.Builder
+	for _, step := range p {
+		switch step := step.(type) {
+		case cmp.MapIndex:
+			sb.WriteByte('/')
+			sb.WriteString(jsonPointerEscaper.Replace(step.Key().String()))
+		case cmp.SliceIndex:
+			ix, iy := step.SplitKeys()
+			if ix < 0 {
+				ix = iy
+			}
+			sb.WriteByte('/')
+			sb.WriteString(strconv.Itoa(ix))
+		}
+	}
+	if sb.Len() == 0 {
+		return "(document root)"
+	}
+	return sb.String()
+}
+
+var jsonPointerEscaper = strings.NewReplacer("~", "~0", "/", "~1")
+
+// renderJSONValue renders part of a decoded JSON document back to compact
+// JSON, or notes its absence (when the other document has a value here).
+func renderJSONValue(rv reflect.Value) string {
+	if !rv.IsValid() {
+		return "(absent)"
+	}
+	bs, err := json.Marshal(rv.Interface())
+	if err != nil {
+		return fmt.Sprintf("(unrenderable: %s)", err)
+	}
+	return string(bs)
+}
@@ -0,0 +1,66 @@
+package wish
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestShouldEqualJSON(t *testing.T) {
+	t.Run("equal modulo formatting", func(t *testing.T) {
+		_, passed := ShouldEqualJSON(
+			`{"b": [1, 2.50, {"c": null}], "a": "x"}`,
+			[]byte(`{"a":"x","b":[1e0,2.5,{"c":null}]}`),
+		)
+		Wish(t, passed, ShouldEqual, true)
+	})
+	t.Run("large integers are not rounded", func(t *testing.T) {
+		msg, passed := ShouldEqualJSON(`{"n": 9007199254740993}`, json.RawMessage(`{"n": 9007199254740992}`))
+		Wish(t, passed, ShouldEqual, false)
+		shouldStringMatch(t, msg, Dedent(`
+			/n:
+				- 9007199254740993
+				+ 9007199254740992
+		`))
+	})
+	t.Run("differences are reported by pointer", func(t *testing.T) {
+		msg, passed := ShouldEqualJSON(
+			`{"a/b": {"x": 1, "y": [true, "q"]}, "gone": 4}`,
+			`{"a/b": {"x": "1", "y": [true, "r"]}, "new": {"k": []}}`,
+		)
+		Wish(t, passed, ShouldEqual, false)
+		shouldStringMatch(t, msg, Dedent(`
+			/a~1b/x:
+				- 1
+				+ "1"
+			/a~1b/y/1:
+				- "q"
+				+ "r"
+			/gone:
+				- 4
+				+ (absent)
+			/new:
+				- (absent)
+				+ {"k":[]}
+		`))
+	})
+	t.Run("whole documents differ in type", func(t *testing.T) {
+		msg, _ := ShouldEqualJSON(`[]`, `{}`)
+		shouldStringMatch(t, msg, Dedent(`
+			(document root):
+				- []
+				+ {}
+		`))
+	})
+	t.Run("invalid actual", func(t *testing.T) {
+		msg, _ := ShouldEqualJSON(`{"a":`, `{}`)
+		shouldStringMatch(t, msg, "actual value is not valid JSON: unexpected EOF")
+	})
+	t.Run("trailing content", func(t *testing.T) {
+		for _, actual := range []string{`{} {}`, `{} ]`, `{} }`, `[] ,`} {
+			msg, _ := ShouldEqualJSON(actual, `{}`)
+			shouldStringMatch(t, msg, "actual value is not valid JSON: unexpected content after the end of the document")
+		}
+		_, passed := ShouldEqualJSON("{}\n\t ", `{}`)
+		Wish(t, passed, ShouldEqual, true)
+	})
+}
@@ -0,0 +1,109 @@
+package wish
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/warpfork/go-wish/cmp"
+	"github.com/warpfork/go-wish/cmp/cmpopts"
+	"github.com/warpfork/go-wish/internal/norm"
+)
+
+// normalization is a named function that ShouldEqual applies to strings
+// before comparing them.
+type normalization struct {
+	name string
+	fn   func(string) string
+}
+
+// NormalizeLineEndings makes ShouldEqual treat "\r\n" and "\r" line endings
+// as "\n" when comparing strings.
+func NormalizeLineEndings() Option {
+	return normalizeOption("NormalizeLineEndings", normalization{"line endings", normalizeLineEndings})
+}
+
+// TrimTrailingSpace makes ShouldEqual ignore whitespace at the end of each
+// line when comparing strings.
+func TrimTrailingSpace() Option {
+	return normalizeOption("TrimTrailingSpace", normalization{"trailing whitespace", trimTrailingSpace})
+}
+
+// NormalizeUnicode makes ShouldEqual convert strings to unicode
+// normalization form C (NFC) before comparing them, so that text which
+// renders the same is equal, however its characters were composed:
+// for example, "é" as a single code point (as in NFC) or as "e" followed by
+// a combining accent (as in NFD).
+func NormalizeUnicode() Option {
+	return normalizeOption("NormalizeUnicode", normalization{"unicode (to NFC)", norm.NFC.String})
+}
+
+// NormalizeWith makes ShouldEqual apply any function to strings before
+// comparing them.  The description is used to say what was done,
+// should the check be rejected.
+func NormalizeWith(description string, fn func(string) string) Option {
+	if fn == nil {
+		panic("NormalizeWith requires a function")
+	}
+	return normalizeOption("NormalizeWith", normalization{description, fn})
+}
+
+func normalizeOption(optName string, n normalization) Option {
+	return optionFunc(func(cfg *config) {
+		cfg.normalizations = append(cfg.normalizations, n)
+		cfg.checkerSpecific = append(cfg.checkerSpecific, optName)
+	})
+}
+
+// normalize applies each of the configured normalizations to s, in order.
+func (cfg *config) normalize(s string) string {
+	for _, n := range cfg.normalizations {
+		s = n.fn(s)
+	}
+	return s
+}
+
+// normalizingCmpOption applies the configured normalizations to strings
+// found anywhere within the values cmp compares.
+func (cfg *config) normalizingCmpOption() cmp.Option {
+	return cmpopts.AcyclicTransformer("normalize", cfg.normalize)
+}
+
+// describeNormalizations states which normalizations were applied before
+// comparing two strings, and whether they changed either string.
+// It's meant to preface the diff of the normalized strings.
+func (cfg *config) describeNormalizations(actual, desire string) string {
+	var effect string
+	actualChanged, desireChanged := cfg.normalize(actual) != actual, cfg.normalize(desire) != desire
+	switch {
+	case actualChanged && desireChanged:
+		effect = "this changed both the actual and desired values"
+	case actualChanged:
+		effect = "this changed the actual value, but not the desired value"
+	case desireChanged:
+		effect = "this changed the desired value, but not the actual value"
+	default:
+		effect = "this changed neither value; the raw strings differ in the same way"
+	}
+	return cfg.normalizationNames() + " (" + effect + "):\n"
+}
+
+func (cfg *config) normalizationNames() string {
+	names := make([]string, len(cfg.normalizations))
+	for i, n := range cfg.normalizations {
+		names[i] = n.name
+	}
+	return "compared after normalizing " + strings.Join(names, ", ")
+}
+
+func normalizeLineEndings(s string) string {
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+	return strings.ReplaceAll(s, "\r", "\n")
+}
+
+func trimTrailingSpace(s string) string {
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRightFunc(line, unicode.IsSpace)
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,78 @@
+package wish
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNormalizations(t *testing.T) {
+	t.Run("line endings", func(t *testing.T) {
+		rt := &recordingT{}
+		Wish(t, Wish(rt, "a\r\nb\rc", ShouldEqual, "a\nb\nc", NormalizeLineEndings()), ShouldEqual, true)
+	})
+	t.Run("trailing whitespace", func(t *testing.T) {
+		rt := &recordingT{}
+		Wish(t, Wish(rt, "a \t\nb  ", ShouldEqual, "a\nb", TrimTrailingSpace()), ShouldEqual, true)
+		Wish(t, Wish(rt, "a \nb", ShouldEqual, " a\nb", TrimTrailingSpace()), ShouldEqual, false)
+	})
+	t.Run("unicode", func(t *testing.T) {
+		nfd, nfc := "cafe\u0301", "caf\u00e9"
+		rt := &recordingT{}
+		Wish(t, Wish(rt, nfd, ShouldEqual, nfc, NormalizeUnicode()), ShouldEqual, true)
+		Wish(t, Wish(rt, nfd, ShouldEqual, nfc), ShouldEqual, false)
+		diff, _ := shouldEqual(buildConfig([]Option{NormalizeUnicode()}), nfd, "cafe")
+		shouldStringMatch(t, diff, Dedent(`
+			compared after normalizing unicode (to NFC) (this changed the actual value, but not the desired value):
+			@@ -1 +1 @@
+			- café
+			?    ^
+			+ cafe
+			?    ^
+		`))
+	})
+	t.Run("custom normalization", func(t *testing.T) {
+		rt := &recordingT{}
+		Wish(t, Wish(rt, "ABC", ShouldEqual, "abc", NormalizeWith("case", strings.ToLower)), ShouldEqual, true)
+	})
+	t.Run("rejection says what was normalized", func(t *testing.T) {
+		cfg := buildConfig([]Option{NormalizeLineEndings(), TrimTrailingSpace()})
+		diff, ok := shouldEqual(cfg, "a \r\nb\r\nc", "a\nx\nc")
+		Wish(t, ok, ShouldEqual, false)
+		shouldStringMatch(t, diff, Dedent(`
+			compared after normalizing line endings, trailing whitespace (this changed the actual value, but not the desired value):
+			@@ -1,3 +1,3 @@
+			  a
+			- b
+			+ x
+			  c
+		`))
+		diff, _ = shouldEqual(cfg, "b", "x")
+		shouldStringMatch(t, diff, Dedent(`
+			compared after normalizing line endings, trailing whitespace (this changed neither value; the raw strings differ in the same way):
+			@@ -1 +1 @@
+			- b
+			+ x
+		`))
+	})
+	t.Run("strings within other values", func(t *testing.T) {
+		type doc struct{ Body []string }
+		cfg := buildConfig([]Option{NormalizeLineEndings()})
+		_, ok := shouldEqual(cfg, doc{[]string{"a\r\n"}}, doc{[]string{"a\n"}})
+		Wish(t, ok, ShouldEqual, true)
+		diff, ok := shouldEqual(cfg, doc{[]string{"a\r\n"}}, doc{[]string{"b\n"}})
+		Wish(t, ok, ShouldEqual, false)
+		Wish(t, diff, ShouldMatchPattern, Dedent(`
+			compared after normalizing line endings in all strings:
+			...
+		`))
+	})
+	for _, check := range []Checker{ShouldContain, ShouldMatchPattern, ShouldEqualUnordered, ShouldMatchSubset} {
+		check, name := check, getCheckerShortName(check)
+		t.Run("not for "+name, func(t *testing.T) {
+			defer func() {
+				shouldStringMatch(t, recover().(string), "the TrimTrailingSpace option cannot be used with the "+name+" checker")
+			}()
+			Wish(t, "a", check, "a", TrimTrailingSpace())
+		})
+	}
+}
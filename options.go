@@ -0,0 +1,277 @@
+package wish
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/warpfork/go-wish/cmp"
+	"github.com/warpfork/go-wish/difflib"
+)
+
+// Option values adjust the behavior of a single Wish or Require call.
+//
+// Options are created by the functions in this package (CmpOptions,
+// ContextLines, Messagef, MaxOutputLines, Colorize, NormalizeLineEndings,
+// and so on); there is deliberately no way to implement Option outside of wish.
+//
+// Some options (like Messagef) apply to any Checker.  Others (like CmpOptions)
+// change how a specific Checker does its comparison, and are only accepted
+// by Checkers which understand them; using them with any other Checker panics,
+// since silently ignoring them would make a check mean something other than
+// it says.  Combinators pass options through to the Checkers they wrap:
+// Not(ShouldEqual) accepts whatever ShouldEqual does, and AllOf and AnyOf
+// give options to each of their Checkers which accepts them (and panic only
+// if none does).
+type Option interface {
+	applyTo(*config)
+}
+
+type optionFunc func(*config)
+
+func (fn optionFunc) applyTo(cfg *config) { fn(cfg) }
+
+// config is the accumulated effect of all the Options given to one call.
+type config struct {
+	cmpOpts       []cmp.Option
+	contextLines  int
+	diffAlgorithm difflib.Algorithm
+	ndiff         bool
+	message       string
+	maxLines      int
+	color         colorMode
+
+	normalizations []normalization
+
+	// checkerSpecific is set by any option that only makes sense
+	// for a Checker found in the configurableCheckers table
+	// (or a combinator wrapping one).
+	checkerSpecific []string
+}
+
+func defaultConfig() *config {
+	return &config{
+		contextLines: 3,
+	}
+}
+
+func buildConfig(opts []Option) *config {
+	cfg := defaultConfig()
+	for _, opt := range opts {
+		opt.applyTo(cfg)
+	}
+	return cfg
+}
+
+// CmpOptions passes options through to go-cmp (see the `cmp` subpackage)
+// when the Checker is ShouldEqual (or ShouldEqualUnordered, or ShouldMatchSubset).
+// This is handy for things like ignoring a field for a single check
+// (using e.g. `cmpopts.IgnoreFields`) without writing a whole new Checker.
+func CmpOptions(opts ...cmp.Option) Option {
+	return optionFunc(func(cfg *config) {
+		cfg.cmpOpts = append(cfg.cmpOpts, opts...)
+		cfg.checkerSpecific = append(cfg.checkerSpecific, "CmpOptions")
+	})
+}
+
+// ContextLines sets how many lines of unchanged context are shown around
+// each change when ShouldEqual (or ShouldMatchPattern) diffs multi-line
+// strings.  The default is 3.
+func ContextLines(n int) Option {
+	if n < 0 {
+		panic("ContextLines must not be negative")
+	}
+	return optionFunc(func(cfg *config) {
+		cfg.contextLines = n
+		cfg.checkerSpecific = append(cfg.checkerSpecific, "ContextLines")
+	})
+}
+
+// DiffAlgorithm chooses the algorithm used when ShouldEqual (or
+// ShouldMatchPattern) diffs multi-line strings.  The default is
+// difflib.RatcliffObershelp, which tends to produce diffs that "look right",
+// but can be slow on large inputs with many repeated lines; difflib.Myers,
+// difflib.Patience, and difflib.Histogram are good choices for those.
+func DiffAlgorithm(alg difflib.Algorithm) Option {
+	if alg == nil {
+		panic("DiffAlgorithm requires an algorithm")
+	}
+	return optionFunc(func(cfg *config) {
+		cfg.diffAlgorithm = alg
+		cfg.checkerSpecific = append(cfg.checkerSpecific, "DiffAlgorithm")
+	})
+}
+
+// NDiff makes ShouldEqual (or ShouldMatchPattern) show the differences between
+// multi-line strings in the style of difflib.NDiff, rather than as a unified
+// diff: every line is shown, not just the changes and some context,
+// and changes within similar lines are marked by "? " guide lines.
+// Lines which differ only by invisible characters are shown escaped, as
+// in the unified diff.  This is often more readable for short strings.
+func NDiff() Option {
+	return optionFunc(func(cfg *config) {
+		cfg.ndiff = true
+		cfg.checkerSpecific = append(cfg.checkerSpecific, "NDiff")
+	})
+}
+
+// Messagef attaches an annotation to the rejection message, if the check is
+// rejected.  This is useful to tell apart checks in a loop, or to say *why*
+// a value is expected, when that's not obvious from the code.
+func Messagef(format string, args ...interface{}) Option {
+	msg := fmt.Sprintf(format, args...)
+	return optionFunc(func(cfg *config) {
+		cfg.message = msg
+	})
+}
+
+// MaxOutputLines limits the number of lines of rejection message logged
+// for a check.  Anything past the limit is replaced by a note saying how many
+// lines were omitted.  Zero (the default) means no limit.
+func MaxOutputLines(n int) Option {
+	if n < 0 {
+		panic("MaxOutputLines must not be negative")
+	}
+	return optionFunc(func(cfg *config) {
+		cfg.maxLines = n
+	})
+}
+
+// configurableChecker is an entry in the configurableCheckers table.
+type configurableChecker struct {
+	// accepts lists the names of the checker-specific options
+	// which the Checker honours.
+	accepts []string
+
+	// build returns the Checker, with its behavior adjusted by config.
+	build func(cfg *config) Checker
+}
+
+// configurableCheckers maps the well-known Checker functions to the options
+// they accept, and a constructor which returns the same Checker, but with its
+// behavior adjusted by config.
+var configurableCheckers = map[uintptr]configurableChecker{
+	funcPointer(ShouldEqual): {
+		accepts: []string{"CmpOptions", "ContextLines", "DiffAlgorithm", "NDiff",
+			"NormalizeLineEndings", "TrimTrailingSpace", "NormalizeUnicode", "NormalizeWith"},
+		build: func(cfg *config) Checker {
+			return func(actual interface{}, desire interface{}) (string, bool) {
+				return shouldEqual(cfg, actual, desire)
+			}
+		},
+	},
+	funcPointer(ShouldEqualUnordered): {
+		accepts: []string{"CmpOptions"},
+		build: func(cfg *config) Checker {
+			return func(actual interface{}, desire interface{}) (string, bool) {
+				return shouldEqualUnordered(cfg, actual, desire)
+			}
+		},
+	},
+	funcPointer(ShouldMatchSubset): {
+		accepts: []string{"CmpOptions"},
+		build: func(cfg *config) Checker {
+			return func(actual interface{}, desire interface{}) (string, bool) {
+				return shouldMatchSubset(cfg, actual, desire)
+			}
+		},
+	},
+	funcPointer(ShouldMatchPattern): {
+		accepts: []string{"ContextLines", "DiffAlgorithm", "NDiff"},
+		build: func(cfg *config) Checker {
+			return func(actual interface{}, desire interface{}) (string, bool) {
+				return shouldMatchPattern(cfg, actual, desire)
+			}
+		},
+	},
+}
+
+func funcPointer(fn Checker) uintptr {
+	return reflect.ValueOf(fn).Pointer()
+}
+
+// configure returns a Checker that behaves like the given one, with the
+// config applied.  Panics if the config contains options that can't apply.
+func (cfg *config) configure(check Checker) Checker {
+	configured, ok := cfg.tryConfigure(check)
+	if !ok {
+		option := cfg.checkerSpecific[0]
+		if c, ok := configurableCheckers[funcPointer(check)]; ok {
+			option = cfg.unaccepted(c.accepts...)
+		}
+		panic(fmt.Sprintf("the %s option cannot be used with the %s checker", option, getCheckerShortName(check)))
+	}
+	return configured
+}
+
+// tryConfigure is configure, but returns false instead of panicking.
+// Checkers built by combinators (like Not) pass the config through to the
+// Checkers they wrap.
+func (cfg *config) tryConfigure(check Checker) (Checker, bool) {
+	if len(cfg.checkerSpecific) == 0 {
+		return check, true
+	}
+	if c, ok := configurableCheckers[funcPointer(check)]; ok {
+		if cfg.unaccepted(c.accepts...) != "" {
+			return nil, false
+		}
+		return c.build(cfg), true
+	}
+	if w := unwrapChecker(check); w != nil {
+		return w.build(cfg)
+	}
+	return nil, false
+}
+
+// unaccepted returns the name of the first checker-specific option in the
+// config which isn't among those accepted, or "" if they all are.
+func (cfg *config) unaccepted(accepts ...string) string {
+	for _, name := range cfg.checkerSpecific {
+		if !hasString(accepts, name) {
+			return name
+		}
+	}
+	return ""
+}
+
+func hasString(list []string, s string) bool {
+	for _, x := range list {
+		if x == s {
+			return true
+		}
+	}
+	return false
+}
+
+// formatRejection composes the message logged for a rejected check.
+// The headline should be something like "ShouldEqual check rejected".
+func (cfg *config) formatRejection(headline string, problem string) string {
+	if cfg.message != "" {
+		headline += " (" + cfg.message + ")"
+	}
+	problem = truncateLines(problem, cfg.maxLines)
+	if cfg.useColor() {
+		problem = colorizeDiff(problem)
+	}
+	return fmt.Sprintf("%s:\n%s", headline, Indent(problem))
+}
+
+// truncateLines keeps the first max lines of s, and replaces the remainder
+// with a note of how many lines were dropped.  A max of zero means no limit.
+func truncateLines(s string, max int) string {
+	if max <= 0 {
+		return s
+	}
+	lines := strings.SplitAfter(s, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	if len(lines) <= max {
+		return s
+	}
+	kept := strings.Join(lines[:max], "")
+	if !strings.HasSuffix(kept, "\n") {
+		kept += "\n"
+	}
+	return kept + fmt.Sprintf("... (%d more lines omitted)\n", len(lines)-max)
+}
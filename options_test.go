@@ -0,0 +1,146 @@
+package wish
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/warpfork/go-wish/cmp"
+	"github.com/warpfork/go-wish/difflib"
+)
+
+// recordingT is a T which keeps what was logged, so tests of wish's own
+// output can look at it.
+type recordingT struct {
+	logs   []string
+	failed bool
+	halted bool
+}
+
+func (*recordingT) Helper()                {}
+func (t *recordingT) Fail()                { t.failed = true }
+func (t *recordingT) FailNow()             { t.failed, t.halted = true, true }
+func (*recordingT) SkipNow()               {}
+func (t *recordingT) Log(x ...interface{}) { t.logs = append(t.logs, fmt.Sprint(x...)) }
+func (*recordingT) Name() string           { return "recordingT" }
+func (t *recordingT) output() string       { return strings.Join(t.logs, "") }
+
+func TestContextLines(t *testing.T) {
+	rt := &recordingT{}
+	Wish(rt, "a\nb\nc\nd\ne", ShouldEqual, "a\nb\nX\nd\ne", ContextLines(1))
+	shouldStringMatch(t, rt.output(), Dedent(`
+		ShouldEqual check rejected:
+			@@ -2,3 +2,3 @@
+			  b
+			- c
+			+ X
+			  d
+	`))
+}
+
+func TestMaxOutputLines(t *testing.T) {
+	rt := &recordingT{}
+	Wish(rt, "a\nb\nc", ShouldEqual, "x\ny\nz", MaxOutputLines(3))
+	shouldStringMatch(t, rt.output(), Dedent(`
+		ShouldEqual check rejected:
+			@@ -1,3 +1,3 @@
+			- a
+			- b
+			... (4 more lines omitted)
+	`))
+}
+
+func TestCheckerSpecificOptionMisuse(t *testing.T) {
+	for _, tc := range []struct {
+		check  Checker
+		option Option
+		panic  string
+	}{
+		{ShouldBeSameTypeAs, CmpOptions(), "the CmpOptions option cannot be used with the ShouldBeSameTypeAs checker"},
+		{ShouldMatchPattern, CmpOptions(), "the CmpOptions option cannot be used with the ShouldMatchPattern checker"},
+		{ShouldEqualUnordered, ContextLines(2), "the ContextLines option cannot be used with the ShouldEqualUnordered checker"},
+		{Not(ShouldEqualUnordered), ContextLines(2), "the ContextLines option cannot be used with the Not(ShouldEqualUnordered) checker"},
+		{ShouldMatchSubset, NDiff(), "the NDiff option cannot be used with the ShouldMatchSubset checker"},
+	} {
+		func() {
+			defer func() {
+				shouldStringMatch(t, recover().(string), tc.panic)
+			}()
+			Wish(&recordingT{}, "a", tc.check, "a", tc.option)
+		}()
+	}
+}
+
+func TestCheckerSpecificOptionsThroughCombinators(t *testing.T) {
+	type named struct{ Name string }
+	ignoreCase := CmpOptions(cmp.Comparer(strings.EqualFold))
+	t.Run("Not", func(t *testing.T) {
+		rt := &recordingT{}
+		Wish(rt, named{"A"}, Not(ShouldEqual), named{"a"}, ignoreCase)
+		shouldStringMatch(t, rt.output(), "Not(ShouldEqual) check rejected:\n\tShouldEqual check passed, but was expected to be rejected")
+	})
+	t.Run("AllOf", func(t *testing.T) {
+		// Options go to the Checkers which accept them, and no others.
+		rt := &recordingT{}
+		Wish(rt, named{"A"}, AllOf(ShouldBeSameTypeAs, ShouldEqual), named{"a"}, ignoreCase)
+		shouldStringMatch(t, rt.output(), "")
+	})
+	t.Run("ShouldBeWithin", func(t *testing.T) {
+		type point struct {
+			X, Y  float64
+			Label string
+		}
+		rt := &recordingT{}
+		Wish(rt, point{1, 2, "A"}, ShouldBeWithin(0.1), point{1.05, 2, "a"}, ignoreCase)
+		shouldStringMatch(t, rt.output(), "")
+	})
+	t.Run("misuse", func(t *testing.T) {
+		defer func() {
+			shouldStringMatch(t, recover().(string), "the CmpOptions option cannot be used with the AnyOf checker")
+		}()
+		Wish(&recordingT{}, 1, AnyOf(ShouldBeSameTypeAs, Not(ShouldBeSameTypeAs)), 2, CmpOptions())
+	})
+}
+
+func TestDiffAlgorithm(t *testing.T) {
+	rt := &recordingT{}
+	Wish(rt, "b\nx\nb\nx\nc", ShouldEqual, "c\nx\nb\nx\nb", DiffAlgorithm(difflib.Myers), ContextLines(0))
+	shouldStringMatch(t, rt.output(), Dedent(`
+		ShouldEqual check rejected:
+			@@ -1 +1 @@
+			- b
+			+ c
+			@@ -5 +5 @@
+			- c
+			+ b
+	`))
+}
+
+func TestNDiff(t *testing.T) {
+	rt := &recordingT{}
+	Wish(rt, "one\ntwo\nthree\nfour", ShouldEqual, "ore\ntwo\nthree\nfour", NDiff())
+	shouldStringMatch(t, rt.output(), Dedent(`
+		ShouldEqual check rejected:
+			- one
+			?  ^
+			+ ore
+			?  ^
+			  two
+			  three
+			  four
+	`))
+	Wish(t, Wish(rt, "a\nb", ShouldEqual, "a\nb", NDiff()), ShouldEqual, true)
+}
+
+func TestNDiffEscapesInvisibleDifferences(t *testing.T) {
+	rt := &recordingT{}
+	Wish(rt, "a\r\nb", ShouldEqual, "a\nb", NDiff())
+	shouldStringMatch(t, rt.output(), Dedent(`
+		ShouldEqual check rejected:
+			- a\r\n
+			?  --
+			+ a\n
+			  b
+			(lines which differ only by invisible characters are shown escaped)
+	`))
+}
@@ -181,3 +181,23 @@ func TestGoTestOutputFun(t *testing.T) {
 		}
 	})
 }
+
+func TestGoTestOutputBatch_helper(t *testing.T) {
+	if os.Getenv("forked") == "" {
+		t.SkipNow()
+	}
+	b := Batch(t)
+	b.Wish("snafoo", ShouldEqual, "zounds")
+	b.Wish("zebras", ShouldEqual, "cats")
+}
+
+func TestGoTestOutputBatch(t *testing.T) {
+	// The report made on cleanup is attributed to the line calling Batch,
+	// not to anything within wish.
+	nom := execGoTest(t, "TestGoTestOutputBatch_helper", "-vet=off")
+	Wish(t, nom, ShouldMatchPattern, Dedent(`
+		--- FAIL: TestGoTestOutputBatch_helper (N.NNs)
+		    output_test.go:NNN: N of N checks in batch rejected:
+		...
+	`))
+}
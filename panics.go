@@ -0,0 +1,107 @@
+package wish
+
+import (
+	"fmt"
+	"runtime/debug"
+)
+
+var (
+	_ Checker = ShouldPanic
+	_ Checker = ShouldNotPanic
+)
+
+// ShouldPanic asserts that the actual value is a `func()` which panics
+// when called, and that the value it panics with matches the desire.
+//
+// The recovered value is compared to the desire with ShouldEqual semantics,
+// with two conveniences for the common case of panicking with errors:
+// if the recovered value is an error and the desire is a string, the error's
+// message is compared to the string (as by ShouldHaveErrorMessage);
+// and if both are errors, the recovered error must wrap the desired one
+// (as by ShouldWrap).  A nil desire accepts a panic with any value.
+//
+// If the check is rejected, the rejection message includes the stack of
+// the goroutine at the time of the panic.
+func ShouldPanic(actual interface{}, desire interface{}) (problem string, passed bool) {
+	fn, ok := actual.(func())
+	if !ok {
+		panic("ShouldPanic must be used with a func() as the actual value")
+	}
+	panicked, recovered, stack := catchPanic(fn)
+	if !panicked {
+		if desire == nil {
+			return "function did not panic; wanted a panic", false
+		}
+		return "function did not panic; wanted a panic with " + describePanicValue(desire), false
+	}
+	if desire == nil {
+		return "", true
+	}
+	var check Checker = ShouldEqual
+	if _, ok := recovered.(error); ok {
+		switch desire.(type) {
+		case string:
+			check = ShouldHaveErrorMessage
+		case error:
+			check = ShouldWrap
+		}
+	}
+	if problem, passed := check(recovered, desire); !passed {
+		return fmt.Sprintf("function panicked with a different value:\n%spanic stack:\n%s", Indent(withTrailingNewline(problem)), Indent(stack)), false
+	}
+	return "", true
+}
+
+// ShouldNotPanic asserts that the actual value is a `func()` which does not
+// panic when called.  The desire should be nil.
+//
+// If the check is rejected, the rejection message includes the recovered
+// value and the stack of the goroutine at the time of the panic.
+func ShouldNotPanic(actual interface{}, desire interface{}) (problem string, passed bool) {
+	fn, ok := actual.(func())
+	if !ok {
+		panic("ShouldNotPanic must be used with a func() as the actual value")
+	}
+	if desire != nil {
+		panic("ShouldNotPanic must be used with nil as the desired value")
+	}
+	panicked, recovered, stack := catchPanic(fn)
+	if !panicked {
+		return "", true
+	}
+	return fmt.Sprintf("function panicked with %s\npanic stack:\n%s", describePanicValue(recovered), Indent(stack)), false
+}
+
+// catchPanic calls fn, and returns whether it panicked; and if so, what value
+// was recovered and the stack at the time of the panic.
+//
+// A panic with a nil value (which only newer versions of Go turn into a
+// *runtime.PanicNilError) is still reported as a panic.
+func catchPanic(fn func()) (panicked bool, recovered interface{}, stack string) {
+	panicked = true
+	defer func() {
+		if panicked {
+			recovered = recover()
+			stack = string(debug.Stack())
+		}
+	}()
+	fn()
+	panicked = false
+	return
+}
+
+// describePanicValue renders a panic value (or desired panic value)
+// with its type, in a single line.
+func describePanicValue(v interface{}) string {
+	if err, ok := v.(error); ok {
+		return describeError(err)
+	}
+	return fmt.Sprintf("%#v", v)
+}
+
+func withTrailingNewline(s string) string {
+	if s == "" || s[len(s)-1] == '\n' {
+		return s
+	}
+	return s + "\n"
+}
@@ -0,0 +1,65 @@
+package wish
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestShouldPanic(t *testing.T) {
+	t.Run("matching value", func(t *testing.T) {
+		_, passed := ShouldPanic(func() { panic("boom") }, "boom")
+		Wish(t, passed, ShouldEqual, true)
+	})
+	t.Run("any value", func(t *testing.T) {
+		_, passed := ShouldPanic(func() { panic(4) }, nil)
+		Wish(t, passed, ShouldEqual, true)
+	})
+	t.Run("runtime error matched by message", func(t *testing.T) {
+		_, passed := ShouldPanic(func() {
+			var m map[string]int
+			m["x"] = 1
+		}, "assignment to entry in nil map")
+		Wish(t, passed, ShouldEqual, true)
+	})
+	t.Run("wrapped error", func(t *testing.T) {
+		sentinel := errors.New("sentinel")
+		_, passed := ShouldPanic(func() { panic(fmt.Errorf("ctx: %w", sentinel)) }, sentinel)
+		Wish(t, passed, ShouldEqual, true)
+	})
+	t.Run("no panic", func(t *testing.T) {
+		msg, passed := ShouldPanic(func() {}, "boom")
+		Wish(t, passed, ShouldEqual, false)
+		shouldStringMatch(t, msg, `function did not panic; wanted a panic with "boom"`)
+	})
+	t.Run("different value", func(t *testing.T) {
+		msg, passed := ShouldPanic(func() { panic("bang") }, "boom")
+		Wish(t, passed, ShouldEqual, false)
+		head := Dedent(`
+			function panicked with a different value:
+				@@ -1 +1 @@
+				- bang
+				+ boom
+			panic stack:
+		`)
+		if !strings.HasPrefix(msg, head) {
+			t.Errorf("message should begin with:\n%s\nbut was:\n%s", head, msg)
+		}
+		if !strings.Contains(msg, "TestShouldPanic") {
+			t.Errorf("stack should mention the panicking test:\n%s", msg)
+		}
+	})
+}
+
+func TestShouldNotPanic(t *testing.T) {
+	_, passed := ShouldNotPanic(func() {}, nil)
+	Wish(t, passed, ShouldEqual, true)
+
+	msg, passed := ShouldNotPanic(func() { panic("boom") }, nil)
+	Wish(t, passed, ShouldEqual, false)
+	head := "function panicked with \"boom\"\npanic stack:\n"
+	if !strings.HasPrefix(msg, head) {
+		t.Errorf("message should begin with:\n%s\nbut was:\n%s", head, msg)
+	}
+}
@@ -0,0 +1,209 @@
+package wish
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var (
+	_ Checker = ShouldMatchRegexp
+	_ Checker = ShouldMatchPattern
+)
+
+// ShouldMatchRegexp asserts that the actual value is a string which matches
+// the desired regular expression, which may be given as either a string
+// or a *regexp.Regexp.
+//
+// As with regexp.MatchString, the match is not anchored: use `^` and `$`
+// (and the `(?s)` or `(?m)` flags, for multi-line strings) if you need them.
+func ShouldMatchRegexp(actual interface{}, desire interface{}) (problem string, passed bool) {
+	var re *regexp.Regexp
+	switch desire := desire.(type) {
+	case *regexp.Regexp:
+		re = desire
+	case string:
+		re = regexp.MustCompile(desire)
+	default:
+		panic("ShouldMatchRegexp must be used with a string or *regexp.Regexp as the desired value")
+	}
+	s, ok := actual.(string)
+	if !ok {
+		return fmt.Sprintf("got value of type %T; wanted a string", actual), false
+	}
+	if re.MatchString(s) {
+		return "", true
+	}
+	if !strings.Contains(s, "\n") {
+		return fmt.Sprintf("%q does not match regexp %q", s, re.String()), false
+	}
+	return fmt.Sprintf("string does not match regexp %q; the string is:\n%s", re.String(), Indent(withTrailingNewline(s))), false
+}
+
+// ShouldMatchPattern asserts that the actual value is a string which matches
+// the desired "pattern document", line by line.
+//
+// Each line of the pattern document is literal text, except:
+//
+//   - `{{regexp}}` matches whatever the regular expression does (within the
+//     line); braces within the regexp must be balanced or escaped;
+//   - `...` within a line matches any run of characters (within the line);
+//   - a line consisting solely of `...` matches any number of whole lines,
+//     including none.
+//
+// This is useful for output which is mostly predictable,
+// but contains timestamps, temp paths, or other noise.  For example:
+//
+//	Wish(t, logs, ShouldMatchPattern, Dedent(`
+//		started at {{\d+:\d+:\d+}}
+//		...
+//		wrote .../out.txt
+//	`))
+//
+// If the check is rejected, the rejection message is the same diff as
+// ShouldEqual would report for two strings -- but every line which
+// matched its pattern is shown as it actually was, as unchanged context,
+// so only the lines which failed to match are highlighted.
+//
+// ShouldMatchPattern accepts the ContextLines, DiffAlgorithm, and NDiff options.
+func ShouldMatchPattern(actual interface{}, desire interface{}) (problem string, passed bool) {
+	return shouldMatchPattern(defaultConfig(), actual, desire)
+}
+
+func shouldMatchPattern(cfg *config, actual interface{}, desire interface{}) (problem string, passed bool) {
+	doc, ok := desire.(string)
+	if !ok {
+		panic("ShouldMatchPattern must be used with a string as the desired value")
+	}
+	s, ok := actual.(string)
+	if !ok {
+		return fmt.Sprintf("got value of type %T; wanted a string", actual), false
+	}
+	patterns := strings.Split(doc, "\n")
+	lines := strings.Split(s, "\n")
+	matchers := make([]*regexp.Regexp, len(patterns))
+	for i, p := range patterns {
+		if p != "..." {
+			matchers[i] = compileLinePattern(p)
+		}
+	}
+
+	// cost[i][j] is the fewest mismatches when aligning patterns[i:] with
+	// lines[j:]: each pattern paired with a line it doesn't match, and each
+	// pattern or line left unpaired, counts as one.  Zero means a complete match.
+	cost := make([][]int, len(patterns)+1)
+	for i := range cost {
+		cost[i] = make([]int, len(lines)+1)
+	}
+	matches := func(i, j int) bool {
+		return matchers[i] != nil && matchers[i].MatchString(lines[j])
+	}
+	for i := len(patterns); i >= 0; i-- {
+		for j := len(lines); j >= 0; j-- {
+			switch {
+			case i == len(patterns):
+				cost[i][j] = len(lines) - j
+			case j == len(lines):
+				cost[i][j] = 1 + cost[i+1][j]
+				if matchers[i] == nil {
+					cost[i][j] = cost[i+1][j]
+				}
+			case matchers[i] == nil:
+				cost[i][j] = min(cost[i+1][j], cost[i][j+1])
+			case matches(i, j):
+				cost[i][j] = cost[i+1][j+1]
+			default:
+				cost[i][j] = 1 + min(cost[i+1][j+1], min(cost[i+1][j], cost[i][j+1]))
+			}
+		}
+	}
+	if cost[0][0] == 0 {
+		return "", true
+	}
+
+	// Trace the best alignment, building a version of the desire in which
+	// every pattern that matched is replaced by the line it matched.
+	// Multi-line wildcards are lazy: when it's no worse for a wildcard to
+	// stop, it does, so the line where the match breaks down is paired with
+	// the pattern that failed to match it, rather than hidden in the wildcard.
+	resolved := make([]string, 0, len(patterns))
+	for i, j := 0, 0; i < len(patterns); {
+		switch {
+		case matchers[i] == nil && (j == len(lines) || cost[i][j] == cost[i+1][j]):
+			i++
+		case matchers[i] == nil:
+			resolved = append(resolved, lines[j])
+			j++
+		case j < len(lines) && matches(i, j) && cost[i][j] == cost[i+1][j+1]:
+			resolved = append(resolved, lines[j])
+			i, j = i+1, j+1
+		case j < len(lines) && cost[i][j] == 1+cost[i+1][j+1]:
+			resolved = append(resolved, patterns[i])
+			i, j = i+1, j+1
+		case j < len(lines) && cost[i][j] == 1+cost[i][j+1]:
+			j++
+		default:
+			resolved = append(resolved, patterns[i])
+			i++
+		}
+	}
+	return cfg.strdiff(s, strings.Join(resolved, "\n")), false
+}
+
+// compileLinePattern turns one line of a pattern document into an anchored
+// regular expression.
+func compileLinePattern(line string) *regexp.Regexp {
+	p := line
+	var sb strings.Builder
+	sb.WriteString("^")
+	for len(p) > 0 {
+		open := strings.Index(p, "{{")
+		dots := strings.Index(p, "...")
+		switch {
+		case open >= 0 && (dots < 0 || open < dots):
+			close := closingBraces(p[open+2:])
+			if close < 0 {
+				panic(fmt.Sprintf("ShouldMatchPattern: unclosed {{ in pattern line %q", line))
+			}
+			sb.WriteString(regexp.QuoteMeta(p[:open]))
+			sb.WriteString("(?:" + p[open+2:open+2+close] + ")")
+			p = p[open+2+close+2:]
+		case dots >= 0:
+			sb.WriteString(regexp.QuoteMeta(p[:dots]))
+			sb.WriteString(".*")
+			p = p[dots+3:]
+		default:
+			sb.WriteString(regexp.QuoteMeta(p))
+			p = ""
+		}
+	}
+	sb.WriteString("$")
+	re, err := regexp.Compile(sb.String())
+	if err != nil {
+		panic(fmt.Sprintf("ShouldMatchPattern: invalid regexp in pattern line %q: %s", line, err))
+	}
+	return re
+}
+
+// closingBraces finds the "}}" which closes a `{{regexp}}`, given the text
+// following the "{{".  Braces within the regexp (as in `a{2}`) are balanced,
+// and escaped ones (as in `\}`) are skipped, so `{{a{2}}}` and `{{\}\}}}`
+// both work.  Returns -1 if there's no closing "}}".
+func closingBraces(s string) int {
+	depth := 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '{':
+			depth++
+		case '}':
+			if depth > 0 {
+				depth--
+			} else if i+1 < len(s) && s[i+1] == '}' {
+				return i
+			}
+		}
+	}
+	return -1
+}
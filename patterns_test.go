@@ -0,0 +1,66 @@
+package wish
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestShouldMatchRegexp(t *testing.T) {
+	_, passed := ShouldMatchRegexp("took 12ms", `^took \d+ms$`)
+	Wish(t, passed, ShouldEqual, true)
+	_, passed = ShouldMatchRegexp("took 12ms", regexp.MustCompile(`\d+`))
+	Wish(t, passed, ShouldEqual, true)
+	msg, _ := ShouldMatchRegexp("took 12s", `^took \d+ms$`)
+	shouldStringMatch(t, msg, `"took 12s" does not match regexp "^took \\d+ms$"`)
+}
+
+func TestShouldMatchPattern(t *testing.T) {
+	t.Run("matching", func(t *testing.T) {
+		_, passed := ShouldMatchPattern(
+			"started at 12:04:55\nloading a\nloading b\nwrote /tmp/x123/out.txt\n",
+			"started at {{\\d+:\\d+:\\d+}}\n...\nwrote .../out.txt\n",
+		)
+		Wish(t, passed, ShouldEqual, true)
+	})
+	t.Run("multi-line wildcard may match nothing", func(t *testing.T) {
+		_, passed := ShouldMatchPattern("a\nb", "a\n...\nb")
+		Wish(t, passed, ShouldEqual, true)
+	})
+	t.Run("mismatch after a multi-line wildcard", func(t *testing.T) {
+		msg, passed := ShouldMatchPattern(
+			"started at 12:04:55\nloading a\nloading b\nfailed\n",
+			"started at {{\\d+:\\d+:\\d+}}\n...\nwrote .../out.txt\n",
+		)
+		Wish(t, passed, ShouldEqual, false)
+		shouldStringMatch(t, msg, Dedent(`
+			@@ -1,5 +1,5 @@
+			  started at 12:04:55
+			  loading a
+			  loading b
+			- failed
+			+ wrote .../out.txt
+			  
+		`))
+	})
+	t.Run("mismatch only highlights failing lines", func(t *testing.T) {
+		msg, passed := ShouldMatchPattern(
+			"started at 12:04:55\nloading a\nfailed\n",
+			"started at {{\\d+:\\d+:\\d+}}\nloading ...\nwrote .../out.txt\n",
+		)
+		Wish(t, passed, ShouldEqual, false)
+		shouldStringMatch(t, msg, Dedent(`
+			@@ -1,4 +1,4 @@
+			  started at 12:04:55
+			  loading a
+			- failed
+			+ wrote .../out.txt
+			  
+		`))
+	})
+	t.Run("regexps may contain braces", func(t *testing.T) {
+		_, passed := ShouldMatchPattern("aa}b", "{{a{2}}}{{\\}}}b")
+		Wish(t, passed, ShouldEqual, true)
+		_, passed = ShouldMatchPattern("aab", "{{a{2}}}b")
+		Wish(t, passed, ShouldEqual, true)
+	})
+}
@@ -0,0 +1,94 @@
+package wish
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+)
+
+// RecordFileEnvVar is the name of the environment variable which enables
+// machine-readable failure records.
+//
+// If this variable is set to a filename, then in addition to the usual
+// rejection message, every rejected check appends one line of JSON to that
+// file (creating it if necessary), describing the rejection: the checker name,
+// the file and line of the check, the test name, the actual and desired
+// values, and the rejection message.  This is intended for CI systems which
+// want to aggregate assertion failures across many tests and packages
+// (the file is only ever appended to, so one file can collect records from
+// every package's test binary).
+//
+// The human-readable output of wish is the same whether or not this is set.
+const RecordFileEnvVar = "WISH_RECORD_FILE"
+
+// failureRecord is the JSON structure of one line of the record file.
+type failureRecord struct {
+	Checker string `json:"checker"`
+	File    string `json:"file"`
+	Line    int    `json:"line"`
+	Test    string `json:"test"`
+	Actual  string `json:"actual"`
+	Desired string `json:"desired"`
+	Diff    string `json:"diff"`
+	Message string `json:"message,omitempty"`
+	Halted  bool   `json:"halted"`
+}
+
+var recordSink struct {
+	sync.Mutex
+	filename string
+	file     *os.File
+}
+
+// recordRejection writes a failure record, if enabled by RecordFileEnvVar.
+// The site is that of the call to the exported function making the check.
+//
+// Problems with the record file itself are reported on stderr, rather than
+// failing the test, since they're not the fault of the test.
+func recordRejection(site callSite, t T, checker string, actual, desired interface{}, problem string, cfg *config, halted bool) {
+	filename := os.Getenv(RecordFileEnvVar)
+	if filename == "" {
+		return
+	}
+	rec := failureRecord{
+		Checker: checker,
+		Test:    t.Name(),
+		Actual:  fmt.Sprintf("%#v", actual),
+		Desired: fmt.Sprintf("%#v", desired),
+		Diff:    problem,
+		Message: cfg.message,
+		Halted:  halted,
+	}
+	if file, line, ok := site.position(); ok {
+		rec.File, rec.Line = file, line
+	}
+	bs, err := json.Marshal(rec)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "wish: could not encode failure record: %s\n", err)
+		return
+	}
+	bs = append(bs, '\n')
+
+	recordSink.Lock()
+	defer recordSink.Unlock()
+	if recordSink.filename != filename {
+		if recordSink.file != nil {
+			recordSink.file.Close()
+		}
+		recordSink.filename = filename
+		recordSink.file, err = os.OpenFile(filename, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0644)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "wish: could not open failure record file: %s\n", err)
+			recordSink.file = nil
+		}
+	}
+	if recordSink.file == nil {
+		return
+	}
+	// One write per record, so that with O_APPEND, records from concurrent
+	// test binaries don't interleave.
+	if _, err := recordSink.file.Write(bs); err != nil {
+		fmt.Fprintf(os.Stderr, "wish: could not write failure record: %s\n", err)
+	}
+}
@@ -0,0 +1,51 @@
+package wish
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFailureRecords(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "records.jsonl")
+	t.Setenv(RecordFileEnvVar, filename)
+
+	rt := &recordingT{}
+	Wish(rt, "a", ShouldEqual, "a")
+	Wish(rt, 1, ShouldEqual, 2, Messagef("note"))
+	b := Batch(rt)
+	b.Wish([]int{1}, ShouldHaveLength, 2)
+	b.Done()
+
+	f, err := os.Open(filename)
+	Require(t, err, ShouldEqual, nil)
+	defer f.Close()
+	var records []failureRecord
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var rec failureRecord
+		Require(t, json.Unmarshal(scanner.Bytes(), &rec), ShouldEqual, nil)
+		Wish(t, filepath.Base(rec.File), ShouldEqual, "records_test.go")
+		rec.File, rec.Line = "", 0
+		records = append(records, rec)
+	}
+	Wish(t, records, ShouldEqual, []failureRecord{
+		{
+			Checker: "ShouldEqual",
+			Test:    "recordingT",
+			Actual:  "1",
+			Desired: "2",
+			Diff:    "  int(\n- \t1,\n+ \t2,\n  )\n",
+			Message: "note",
+		},
+		{
+			Checker: "ShouldHaveLength",
+			Test:    "recordingT",
+			Actual:  "[]int{1}",
+			Desired: "2",
+			Diff:    "got length 1; wanted 2",
+		},
+	})
+}
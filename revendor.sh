@@ -35,24 +35,18 @@ patch cmp/report_text.go <<EOF
 EOF
 
 
-git clone https://github.com/pmezard/go-difflib .tmp/go-difflib
-cp -r .tmp/go-difflib/difflib/ .
-cp .tmp/go-difflib/LICENSE difflib
+# Unicode normalization (for the NormalizeUnicode option) comes from x/text.
+git clone --depth 1 --branch v0.37.0 https://github.com/golang/text .tmp/text
+mkdir -p internal/norm internal/transform
+cp .tmp/text/unicode/norm/*.go internal/norm/
+cp .tmp/text/transform/*.go internal/transform/
+rm -f internal/norm/*_test.go internal/transform/*_test.go internal/norm/maketables.go internal/norm/triegen.go
+for dir in internal/norm internal/transform; do cp .tmp/text/LICENSE .tmp/text/PATENTS $dir; done
+find internal/ -type f -name \*.go -print0 | xargs -0 sed -i \
+	-e "s#golang.org/x/text/transform#github.com/warpfork/go-wish/internal/transform#" \
+	-e 's#^\(package [a-z]*\) // import "[^"]*"#\1#'
 
-patch difflib/difflib.go <<EOF
-@@ -608,3 +608,3 @@ func WriteUnifiedDiff(writer io.Writer, diff UnifiedDiff) error {
- 				for _, line := range diff.A[i1:i2] {
--					if err := ws(" " + line); err != nil {
-+					if err := ws("  " + line); err != nil {
- 						return err
-@@ -616,3 +616,3 @@ func WriteUnifiedDiff(writer io.Writer, diff UnifiedDiff) error {
- 				for _, line := range diff.A[i1:i2] {
--					if err := ws("-" + line); err != nil {
-+					if err := ws("- " + line); err != nil {
- 						return err
-@@ -623,3 +623,3 @@ func WriteUnifiedDiff(writer io.Writer, diff UnifiedDiff) error {
- 				for _, line := range diff.B[j1:j2] {
--					if err := ws("+" + line); err != nil {
-+					if err := ws("+ " + line); err != nil {
- 						return err
-EOF
+# The difflib package was vendored from https://github.com/pmezard/go-difflib,
+# but has since been extended here (more diff algorithms, ndiff, parsing and
+# applying patches, and a choice of output formats), so it's no longer
+# re-vendored.
@@ -0,0 +1,206 @@
+package wish
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"runtime"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// maxExpressionLen is the longest source expression that callSite.describe
+// will quote; longer (or multi-line) expressions are abbreviated.
+const maxExpressionLen = 60
+
+// callSite identifies a call to one of wish's exported functions (like Wish).
+// Those functions capture it on entry, by calling here, and pass it down,
+// so that nothing else needs to count stack frames to find the caller.
+type callSite struct {
+	pcs  [2]uintptr // of the wish function, and of its caller
+	n    int
+	args argPositions
+}
+
+// argPositions says which arguments of a wish function are the actual
+// and desired values.
+type argPositions struct {
+	actual, desired int
+}
+
+var (
+	// argsWithT is for Wish(t, actual, check, desired, ...) and its relatives,
+	// including Check, Eventually, and Consistently.
+	argsWithT = argPositions{actual: 1, desired: 3}
+
+	// argsOfBatcher is for Batcher.Wish(actual, check, desired, ...).
+	argsOfBatcher = argPositions{actual: 0, desired: 2}
+)
+
+// here returns the callSite of the function that calls here, which should
+// be one of wish's exported functions.  Only program counters are gathered,
+// so it's cheap; the rest of the work is done if the check is rejected.
+func here(args argPositions) callSite {
+	site := callSite{args: args}
+	site.n = runtime.Callers(2, site.pcs[:]) // skip runtime.Callers and here
+	return site
+}
+
+// frames returns the frames of the wish function and of its caller.
+func (site callSite) frames() (fn, caller runtime.Frame, ok bool) {
+	frames := runtime.CallersFrames(site.pcs[:site.n])
+	fn, more := frames.Next()
+	if !more {
+		return fn, caller, false
+	}
+	caller, _ = frames.Next()
+	return fn, caller, true
+}
+
+// position returns the file and line of the call.
+func (site callSite) position() (file string, line int, ok bool) {
+	_, caller, ok := site.frames()
+	return caller.File, caller.Line, ok
+}
+
+// describe finds the source code of the call, and returns the source text of
+// its actual and desired arguments, formatted like "(got, want)", for use in
+// rejection messages.
+//
+// If the source can't be found or parsed, or the call can't be identified
+// unambiguously, or both arguments are just literals (in which case the rest
+// of the message already shows them), the empty string is returned.
+func (site callSite) describe() string {
+	fn, caller, ok := site.frames()
+	if !ok {
+		return ""
+	}
+	fnName := strings.Replace(fn.Function, "[...]", "", -1)
+	fnName = fnName[strings.LastIndex(fnName, ".")+1:]
+
+	src := loadSource(caller.File)
+	if src == nil {
+		return ""
+	}
+	call := src.findCall(caller.Line, fnName)
+	if call == nil {
+		return ""
+	}
+	exprs := make([]string, 0, 2)
+	allLiteral := true
+	for _, i := range []int{site.args.actual, site.args.desired} {
+		if i >= len(call.Args) {
+			return ""
+		}
+		arg := call.Args[i]
+		switch arg.(type) {
+		case *ast.BasicLit, *ast.CompositeLit:
+		default:
+			allLiteral = false
+		}
+		exprs = append(exprs, src.text(arg))
+	}
+	if allLiteral {
+		return ""
+	}
+	return "(" + strings.Join(exprs, ", ") + ")"
+}
+
+// sourceFile is a parsed source file, as cached by loadSource.
+type sourceFile struct {
+	fset  *token.FileSet
+	file  *ast.File
+	bytes []byte
+}
+
+var (
+	sourceCacheMu sync.Mutex
+	sourceCache   = map[string]*sourceFile{}
+)
+
+// loadSource returns the parsed source of a file, or nil if it can't be read
+// or parsed.  Results (including failures) are cached, so each file
+// is only parsed once per process.
+func loadSource(filename string) *sourceFile {
+	sourceCacheMu.Lock()
+	defer sourceCacheMu.Unlock()
+	if src, ok := sourceCache[filename]; ok {
+		return src
+	}
+	var src *sourceFile
+	if bs, err := os.ReadFile(filename); err == nil {
+		fset := token.NewFileSet()
+		if file, err := parser.ParseFile(fset, filename, bs, 0); err == nil {
+			src = &sourceFile{fset, file, bs}
+		}
+	}
+	sourceCache[filename] = src
+	return src
+}
+
+// findCall returns the call expression on the given line whose function
+// has the given name, if there's exactly one such call.
+func (src *sourceFile) findCall(line int, fnName string) *ast.CallExpr {
+	var found *ast.CallExpr
+	ambiguous := false
+	ast.Inspect(src.file, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		if src.fset.Position(call.Lparen).Line != line && src.fset.Position(call.Pos()).Line != line {
+			return true
+		}
+		if calledName(call.Fun) != fnName {
+			return true
+		}
+		if found != nil {
+			ambiguous = true
+		}
+		found = call
+		return true
+	})
+	if ambiguous {
+		return nil
+	}
+	return found
+}
+
+// calledName returns the name of the called function in a call expression,
+// ignoring any package qualifier, receiver, or type arguments.
+func calledName(fn ast.Expr) string {
+	switch fn := fn.(type) {
+	case *ast.Ident:
+		return fn.Name
+	case *ast.SelectorExpr:
+		return fn.Sel.Name
+	case *ast.IndexExpr:
+		return calledName(fn.X)
+	case *ast.IndexListExpr:
+		return calledName(fn.X)
+	default:
+		return ""
+	}
+}
+
+// text returns the source text of a node, abbreviated if it's long.
+func (src *sourceFile) text(n ast.Node) string {
+	start := src.fset.Position(n.Pos()).Offset
+	end := src.fset.Position(n.End()).Offset
+	s := string(src.bytes[start:end])
+	if strings.Contains(s, "\n") || len(s) > maxExpressionLen {
+		s = strings.SplitN(s, "\n", 2)[0]
+		if len(s) > maxExpressionLen {
+			// Cut on a rune boundary, so as not to produce invalid UTF-8.
+			cut := maxExpressionLen
+			for cut > 0 && !utf8.RuneStart(s[cut]) {
+				cut--
+			}
+			s = s[:cut]
+		}
+		s += "..."
+	}
+	return s
+}
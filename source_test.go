@@ -0,0 +1,61 @@
+package wish
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func firstLine(s string) string {
+	return strings.SplitN(s, "\n", 2)[0]
+}
+
+func TestSourceExpressions(t *testing.T) {
+	t.Run("variables are quoted", func(t *testing.T) {
+		rt := &recordingT{}
+		got, want := "a", "b"
+		Wish(rt, got, ShouldEqual, want)
+		shouldStringMatch(t, firstLine(rt.logs[0]), "ShouldEqual(got, want) check rejected:")
+	})
+	t.Run("literals are not quoted", func(t *testing.T) {
+		rt := &recordingT{}
+		Wish(rt, "a", ShouldEqual, "b")
+		shouldStringMatch(t, firstLine(rt.logs[0]), "ShouldEqual check rejected:")
+	})
+	t.Run("long and multi-line expressions are abbreviated", func(t *testing.T) {
+		rt := &recordingT{}
+		type pair struct{ A, B int }
+		x := pair{1, 2}
+		Require(rt, x, ShouldEqual, pair{
+			A: 1,
+			B: 3,
+		})
+		shouldStringMatch(t, firstLine(rt.logs[0]), "halting: critical ShouldEqual(x, pair{...) check rejected:")
+	})
+	t.Run("abbreviation does not split runes", func(t *testing.T) {
+		rt := &recordingT{}
+		héé := map[string]string{"ééééééééééééééééééééééééééééééééééééééééééééééééééééééééééé": "x"}
+		Wish(rt, héé["ééééééééééééééééééééééééééééééééééééééééééééééééééééééééééé"], ShouldEqual, "y")
+		line := firstLine(rt.logs[0])
+		if !utf8.ValidString(line) {
+			t.Errorf("invalid UTF-8 in %q", line)
+		}
+		shouldStringMatch(t, line, `ShouldEqual(héé["`+strings.Repeat("é", 26)+`..., "y") check rejected:`)
+	})
+	t.Run("generic and batch calls", func(t *testing.T) {
+		rt := &recordingT{}
+		n := int64(4)
+		Check(rt, n, ShouldEqual, 5)
+		b := Batch(rt)
+		b.Wish(n, ShouldEqual, int64(5))
+		b.Done()
+		shouldStringMatch(t, firstLine(rt.logs[0]), "ShouldEqual(n, 5) check rejected:")
+		Wish(t, strings.Split(rt.logs[1], "\n")[1], ShouldMatchPattern, `#1 ShouldEqual(n, int64(5)) check rejected at source_test.go:{{\d+}}:`)
+	})
+	t.Run("two calls on one line are ambiguous", func(t *testing.T) {
+		rt := &recordingT{}
+		x := 1
+		_ = Wish(rt, x, ShouldEqual, 2) || Wish(rt, x, ShouldEqual, 3)
+		shouldStringMatch(t, firstLine(rt.logs[0]), "ShouldEqual check rejected:")
+	})
+}
@@ -0,0 +1,55 @@
+package wish
+
+import (
+	"github.com/warpfork/go-wish/cmp"
+)
+
+var (
+	_ Checker = ShouldMatchSubset
+)
+
+// ShouldMatchSubset asserts that the actual value matches the desire,
+// examining the values recursively in the same way as ShouldEqual --
+// except that any part of the desire which is left unset is not checked.
+//
+// Concretely, struct fields which have their zero value in the desire are
+// ignored, as are any map entries in the actual value whose key is absent
+// from the desired map.  This applies at every depth, so it's easy to
+// assert on only a few fields of a large value:
+//
+//	Wish(t, resp, ShouldMatchSubset, Response{Status: 200, Body: Body{Kind: "ok"}})
+//
+// Slices are still compared element by element (and must have equal lengths),
+// though the elements themselves are matched as subsets too.
+//
+// Note that this means it's impossible to use ShouldMatchSubset to assert
+// that a field *is* its zero value; use ShouldEqual for those fields.
+//
+// The rejection message is the same diff as ShouldEqual would report, but
+// covering only the parts of the value which the desire constrains.
+//
+// ShouldMatchSubset accepts the CmpOptions option.
+func ShouldMatchSubset(actual interface{}, desire interface{}) (diff string, eq bool) {
+	return shouldMatchSubset(defaultConfig(), actual, desire)
+}
+
+func shouldMatchSubset(cfg *config, actual interface{}, desire interface{}) (diff string, eq bool) {
+	opts := append([]cmp.Option{ignoreUnconstrained}, cfg.cmpOpts...)
+	diff = cmp.Diff(actual, desire, opts...)
+	return diff, diff == ""
+}
+
+// ignoreUnconstrained is a cmp option which ignores zero struct fields and
+// absent map entries in the desire (which is always the "y" side).
+var ignoreUnconstrained = cmp.FilterPath(func(p cmp.Path) bool {
+	switch step := p.Last().(type) {
+	case cmp.StructField:
+		_, vy := step.Values()
+		return vy.IsValid() && vy.IsZero()
+	case cmp.MapIndex:
+		_, vy := step.Values()
+		return !vy.IsValid()
+	default:
+		return false
+	}
+}, cmp.Ignore())
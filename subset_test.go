@@ -0,0 +1,60 @@
+package wish
+
+import (
+	"testing"
+)
+
+type subsetFixture struct {
+	ID     int
+	Name   string
+	Tags   map[string]string
+	Inner  *subsetFixture
+	Scores []int
+}
+
+func TestShouldMatchSubset(t *testing.T) {
+	actual := subsetFixture{
+		ID:     7,
+		Name:   "seven",
+		Tags:   map[string]string{"a": "1", "b": "2"},
+		Inner:  &subsetFixture{ID: 8, Name: "eight"},
+		Scores: []int{1, 2},
+	}
+	t.Run("only constrained fields checked", func(t *testing.T) {
+		_, passed := ShouldMatchSubset(actual, subsetFixture{
+			Name:  "seven",
+			Tags:  map[string]string{"b": "2"},
+			Inner: &subsetFixture{ID: 8},
+		})
+		Wish(t, passed, ShouldEqual, true)
+	})
+	t.Run("empty desire matches anything", func(t *testing.T) {
+		_, passed := ShouldMatchSubset(actual, subsetFixture{})
+		Wish(t, passed, ShouldEqual, true)
+	})
+	t.Run("constrained field differs", func(t *testing.T) {
+		msg, passed := ShouldMatchSubset(actual, subsetFixture{
+			Tags:  map[string]string{"b": "3", "c": "4"},
+			Inner: &subsetFixture{Name: "ate"},
+		})
+		Wish(t, passed, ShouldEqual, false)
+		shouldStringMatch(t, msg, Dedent(`
+			  wish.subsetFixture{
+			  	... // 2 ignored fields
+			  	Tags: map[string]string{
+			  		... // 1 ignored entry
+			- 		"b": "2",
+			+ 		"b": "3",
+			+ 		"c": "4",
+			  	},
+			  	Inner: &wish.subsetFixture{
+			  		... // 1 ignored field
+			- 		Name: "eight",
+			+ 		Name: "ate",
+			  		... // 3 ignored fields
+			  	},
+			  	... // 1 ignored field
+			  }
+		`))
+	})
+}
@@ -6,11 +6,107 @@ import (
 	"strings"
 )
 
+// getCheckerShortName returns the name of the func, without package path.
+//
+// Checkers returned by this package's combinators and constructors (like Not
+// or ShouldBeWithin) carry their own name, which says what they wrap.
 func getCheckerShortName(fn Checker) string {
-	fqn := runtime.FuncForPC(reflect.ValueOf(fn).Pointer()).Name()
+	if w := unwrapChecker(fn); w != nil {
+		return w.name
+	}
+	return getFuncShortName(fn)
+}
+
+// getFuncShortName is getCheckerShortName for any func value (such as
+// the typed checker functions accepted by Check).
+// Type parameters of generic functions are left out of the name.
+//
+// If the func is a closure returned by a function of this package (such as
+// Untyped), the name of that function is used, so the name is still
+// meaningful.  Other closures (like a func literal in a test, which would
+// otherwise be named for the test) keep the name the compiler gave them.
+func getFuncShortName(fn interface{}) string {
+	f := runtime.FuncForPC(reflect.ValueOf(fn).Pointer())
+	fqn := strings.Replace(f.Name(), "[...]", "", -1)
+	if file, _ := f.FileLine(f.Entry()); strings.HasPrefix(fqn, packagePath+".") && !strings.HasSuffix(file, "_test.go") {
+		for cut := strings.LastIndex(fqn, "."); cut > len(packagePath) && isClosureSuffix(fqn[cut+1:]); cut = strings.LastIndex(fqn, ".") {
+			fqn = fqn[:cut]
+		}
+	}
 	cut := strings.LastIndex(fqn, ".")
 	if cut < 0 {
 		return fqn
 	}
 	return fqn[cut+1:]
 }
+
+// packagePath is the import path of this package, as it appears in
+// the names of its funcs.
+var packagePath = reflect.TypeOf(config{}).PkgPath()
+
+// isClosureSuffix matches the "func1" (or "1", for closures nested in
+// closures) suffixes the compiler uses to name func literals.
+func isClosureSuffix(s string) bool {
+	s = strings.TrimPrefix(s, "func")
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// wrappedChecker describes a Checker built by this package around other
+// Checkers or parameters: its name, and how to build it with a config, so that
+// checker-specific options can be passed through to whatever it wraps.
+type wrappedChecker struct {
+	name string
+
+	// build returns the Checker with the config applied,
+	// or false if none of the config's checker-specific options apply to it.
+	build func(cfg *config) (Checker, bool)
+
+	// plain is the Checker built with the default config.
+	plain Checker
+}
+
+// wrapQuery is given as the actual value to a Checker made by wrapChecker,
+// to ask it for its description rather than to check anything.
+type wrapQuery struct {
+	found *wrappedChecker
+}
+
+// wrapChecker returns a Checker which behaves like build(defaultConfig()),
+// and which unwrapChecker can recover the description from.
+func wrapChecker(name string, build func(cfg *config) (Checker, bool)) Checker {
+	w := &wrappedChecker{name: name, build: build}
+	w.plain, _ = build(defaultConfig())
+	return w.check
+}
+
+func (w *wrappedChecker) check(actual interface{}, desire interface{}) (string, bool) {
+	if q, ok := actual.(*wrapQuery); ok {
+		q.found = w
+		return "", true
+	}
+	return w.plain(actual, desire)
+}
+
+// wrappedCheckerPointer is the code pointer shared by every Checker made by
+// wrapChecker.  (They're all method values of the same method, which, unlike
+// a func literal, isn't copied when the function returning it is inlined.)
+var wrappedCheckerPointer = funcPointer((*wrappedChecker)(nil).check)
+
+// unwrapChecker returns the description of a Checker made by wrapChecker,
+// or nil for any other Checker.
+func unwrapChecker(check Checker) *wrappedChecker {
+	if check == nil || funcPointer(check) != wrappedCheckerPointer {
+		return nil
+	}
+	var q wrapQuery
+	check(&q, nil)
+	return q.found
+}
@@ -7,4 +7,21 @@ func TestGetCheckerShortName(t *testing.T) {
 	if sn != "ShouldBe" {
 		t.Errorf("%q", sn)
 	}
+	sn = getCheckerShortName(Not(ShouldBe))
+	if sn != "Not(ShouldBe)" {
+		t.Errorf("%q", sn)
+	}
+	sn = getCheckerShortName(ShouldBeWithin(0.1))
+	if sn != "ShouldBeWithin" {
+		t.Errorf("%q", sn)
+	}
+	sn = getCheckerShortName(Untyped(ShouldEqualOf[int]))
+	if sn != "Untyped" {
+		t.Errorf("%q", sn)
+	}
+	// A func literal in a test is not named for the test.
+	sn = getCheckerShortName(func(actual interface{}, desire interface{}) (string, bool) { return "", true })
+	if sn != "func1" {
+		t.Errorf("%q", sn)
+	}
 }
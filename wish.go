@@ -1,9 +1,5 @@
 package wish
 
-import (
-	"fmt"
-)
-
 // T is an interface alternative to `*testing.T` -- wherever you see this used,
 // use your `*testing.T` object.
 type T interface {
@@ -30,14 +26,12 @@ type Checker func(actual interface{}, desire interface{}) (problem string, passe
 // Failure to match will log to T, fail the test, and return false (so you
 // may take alternative debugging paths, or handle halting on your own).
 // Failure to match will *not* cause FailNow; execution will continue.
-func Wish(t T, actual interface{}, check Checker, desired interface{}, opts ...options) bool {
+//
+// Options may be given to adjust the check or its rejection message;
+// see the Option type.
+func Wish(t T, actual interface{}, check Checker, desired interface{}, opts ...Option) bool {
 	t.Helper()
-	problemMsg, passed := check(actual, desired)
-	if !passed {
-		t.Log(fmt.Sprintf("%s check rejected:\n%s", getCheckerShortName(check), Indent(problemMsg)))
-		t.Fail()
-	}
-	return passed
+	return assert(here(argsWithT), t, actual, check, getCheckerShortName(check), desired, opts, false)
 }
 
 // Require makes an assertion that two objects match, using criteria defined by a
@@ -66,15 +60,34 @@ func Wish(t T, actual interface{}, check Checker, desired interface{}, opts ...o
 // It's also shorter to type.  Most importantly, this approach produces more
 // useful rejection messages, because it can say what you *do* expect, rather
 // than halting after a less informative check.
-func Require(t T, actual interface{}, check Checker, desired interface{}, opts ...options) {
+func Require(t T, actual interface{}, check Checker, desired interface{}, opts ...Option) {
 	t.Helper()
-	problemMsg, passed := check(actual, desired)
-	if !passed {
-		t.Log(fmt.Sprintf("halting: critical %s check rejected:\n%s", getCheckerShortName(check), Indent(problemMsg)))
-		t.FailNow()
-	}
+	assert(here(argsWithT), t, actual, check, getCheckerShortName(check), desired, opts, true)
 }
 
-type options interface {
-	_options()
+// assert is the shared implementation of Wish and Require (and their
+// relatives, like Check), which differ only in whether they halt.
+// The name of the checker is given separately, since callers which wrap
+// the check will know its name better than getCheckerShortName can.
+//
+// The site is that of the call to the exported function.  When its source
+// code is available, the rejection message also quotes the expressions given
+// for the actual and desired values.
+func assert(site callSite, t T, actual interface{}, check Checker, name string, desired interface{}, opts []Option, halt bool) bool {
+	t.Helper()
+	cfg := buildConfig(opts)
+	problemMsg, passed := cfg.configure(check)(actual, desired)
+	if passed {
+		return true
+	}
+	recordRejection(site, t, name, actual, desired, problemMsg, cfg, halt)
+	name += site.describe()
+	if halt {
+		t.Log(cfg.formatRejection("halting: critical "+name+" check rejected", problemMsg))
+		t.FailNow()
+	} else {
+		t.Log(cfg.formatRejection(name+" check rejected", problemMsg))
+		t.Fail()
+	}
+	return false
 }